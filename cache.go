@@ -0,0 +1,167 @@
+package async
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/denisss025/go-async/pipeline"
+)
+
+// CacheOptions configures a Cache.
+type CacheOptions struct {
+	// TTL is the time successful results are kept. Forever when zero.
+	TTL time.Duration
+	// NegativeTTL is the time errors are kept. Errors are not cached when
+	// zero.
+	NegativeTTL time.Duration
+	// MaxSize limits the number of entries. The least recently used entries
+	// are evicted first. No limit when zero.
+	MaxSize int
+	// Clock is used to expire entries. SystemClock is used when nil.
+	Clock Clock
+}
+
+type cacheEntry[K comparable, V any] struct {
+	key     K
+	future  *Future[V]
+	expires time.Time
+}
+
+// Cache is an asynchronous memoizing cache. Concurrent calls of Get for
+// the same key share a single call of the load function.
+type Cache[K comparable, V any] struct {
+	ctx   context.Context
+	load  func(context.Context, K) (V, error)
+	opts  CacheOptions
+	clock Clock
+
+	mu      sync.Mutex
+	entries map[K]*list.Element
+	lru     *list.List
+}
+
+// NewCache creates a new Cache that loads missing values with function load.
+// Function load runs with the given context, not with the context of
+// a caller.
+func NewCache[K comparable, V any](ctx context.Context,
+	load func(context.Context, K) (V, error), opts CacheOptions) *Cache[K, V] {
+	clock := opts.Clock
+	if clock == nil {
+		clock = SystemClock{}
+	}
+
+	return &Cache[K, V]{
+		ctx:     ctx,
+		load:    load,
+		opts:    opts,
+		clock:   clock,
+		entries: make(map[K]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// Get returns a Future that holds the value for the key. It starts loading
+// the value when it is not cached yet or has expired. Every call returns
+// a new Future, so cancelling it does not affect the other callers.
+func (c *Cache[K, V]) Get(key K) *Future[V] {
+	return continueWith(c.ctx, c.get(key),
+		func(_ context.Context, result pipeline.Result[V]) (V, error) {
+			return result.Val, result.Err
+		})
+}
+
+// get returns the shared Future of the entry for the key.
+func (c *Cache[K, V]) get(key K) *Future[V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*cacheEntry[K, V])
+
+		if entry.expires.IsZero() || c.clock.Now().Before(entry.expires) {
+			c.lru.MoveToFront(elem)
+
+			return entry.future
+		}
+
+		c.remove(elem)
+	}
+
+	entry := &cacheEntry[K, V]{key: key, future: newFuture[V]()}
+	elem := c.lru.PushFront(entry)
+	c.entries[key] = elem
+
+	go func(ctx context.Context, f *Future[V]) {
+		result := pipeline.Result[V]{Err: ctx.Err()}
+		if result.Err == nil {
+			result = run(ctx, func() (V, error) { return c.load(ctx, key) })
+		}
+
+		// The entry is settled before the Future, so the callers that
+		// see the result do not get a stale entry.
+		c.settle(elem, result.Err)
+		f.resolve(result)
+	}(c.ctx, entry.future)
+
+	if c.opts.MaxSize > 0 {
+		for c.lru.Len() > c.opts.MaxSize {
+			c.remove(c.lru.Back())
+		}
+	}
+
+	return entry.future
+}
+
+// Invalidate removes the entry for the key. The Future of an in-flight
+// call stays valid for its holders, but subsequent calls of Get start
+// a new one.
+func (c *Cache[K, V]) Invalidate(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+}
+
+// Len returns the number of entries in the Cache including in-flight and
+// expired ones.
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Len()
+}
+
+// settle sets the expiration time of the entry or removes it when the error
+// must not be cached.
+func (c *Cache[K, V]) settle(elem *list.Element, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := elem.Value.(*cacheEntry[K, V])
+	if c.entries[entry.key] != elem {
+		return
+	}
+
+	ttl := c.opts.TTL
+
+	if err != nil {
+		if ttl = c.opts.NegativeTTL; ttl <= 0 {
+			c.remove(elem)
+
+			return
+		}
+	}
+
+	if ttl > 0 {
+		entry.expires = c.clock.Now().Add(ttl)
+	}
+}
+
+func (c *Cache[K, V]) remove(elem *list.Element) {
+	delete(c.entries, elem.Value.(*cacheEntry[K, V]).key)
+	c.lru.Remove(elem)
+}
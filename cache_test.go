@@ -0,0 +1,221 @@
+package async_test
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/denisss025/go-async"
+	"github.com/stretchr/testify/assert"
+)
+
+type testLoader struct {
+	calls   int32
+	err     error
+	release chan struct{}
+}
+
+func (l *testLoader) Load(_ context.Context, key int) (string, error) {
+	n := atomic.AddInt32(&l.calls, 1)
+
+	if l.release != nil {
+		<-l.release
+	}
+
+	if l.err != nil {
+		return "", l.err
+	}
+
+	return strconv.Itoa(key) + "/" + strconv.Itoa(int(n)), nil
+}
+
+func (l *testLoader) Calls() int {
+	return int(atomic.LoadInt32(&l.calls))
+}
+
+func TestCache(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("deduplicate in-flight calls", func(t *testing.T) {
+		t.Parallel()
+
+		const callers = 10
+
+		loader := &testLoader{release: make(chan struct{})}
+		cache := NewCache(ctx, loader.Load, CacheOptions{})
+
+		futures := make([]*Future[string], callers)
+
+		wg := new(sync.WaitGroup)
+		wg.Add(callers)
+
+		for i := range futures {
+			go func(i int) {
+				defer wg.Done()
+
+				futures[i] = cache.Get(1)
+			}(i)
+		}
+
+		wg.Wait()
+		close(loader.release)
+
+		for _, f := range futures {
+			v, err := f.Await(ctx)
+
+			assert.NoError(t, err)
+			assert.Equal(t, "1/1", v)
+		}
+
+		assert.Equal(t, 1, loader.Calls())
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		t.Parallel()
+
+		loader := &testLoader{release: make(chan struct{})}
+		cache := NewCache(ctx, loader.Load, CacheOptions{})
+
+		cancelled, other := cache.Get(1), cache.Get(1)
+		cancelled.Cancel()
+
+		_, err := cancelled.Await(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		close(loader.release)
+
+		v, err := other.Await(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "1/1", v)
+
+		v, err = cache.Get(1).Await(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "1/1", v)
+	})
+
+	t.Run("get after cancel", func(t *testing.T) {
+		t.Parallel()
+
+		loader := &testLoader{}
+		cache := NewCache(ctx, loader.Load, CacheOptions{})
+
+		cache.Get(1).Cancel()
+
+		v, err := cache.Get(1).Await(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "1/1", v)
+		assert.Equal(t, 1, loader.Calls())
+	})
+
+	t.Run("ttl", func(t *testing.T) {
+		t.Parallel()
+
+		clock := &testClock{}
+		loader := &testLoader{}
+		cache := NewCache(ctx, loader.Load, CacheOptions{
+			TTL:   time.Minute,
+			Clock: clock,
+		})
+
+		v, err := cache.Get(1).Await(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "1/1", v)
+
+		clock.Advance(time.Second)
+
+		v, err = cache.Get(1).Await(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "1/1", v)
+
+		clock.Advance(time.Minute)
+
+		v, err = cache.Get(1).Await(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "1/2", v)
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		t.Parallel()
+
+		loader := &testLoader{err: io.EOF}
+		cache := NewCache(ctx, loader.Load, CacheOptions{})
+
+		_, err := cache.Get(1).Await(ctx)
+		assert.ErrorIs(t, err, io.EOF)
+
+		_, err = cache.Get(1).Await(ctx)
+		assert.ErrorIs(t, err, io.EOF)
+
+		assert.Equal(t, 2, loader.Calls())
+	})
+
+	t.Run("negative ttl", func(t *testing.T) {
+		t.Parallel()
+
+		clock := &testClock{}
+		loader := &testLoader{err: io.EOF}
+		cache := NewCache(ctx, loader.Load, CacheOptions{
+			NegativeTTL: time.Second,
+			Clock:       clock,
+		})
+
+		_, err := cache.Get(1).Await(ctx)
+		assert.ErrorIs(t, err, io.EOF)
+
+		_, err = cache.Get(1).Await(ctx)
+		assert.ErrorIs(t, err, io.EOF)
+		assert.Equal(t, 1, loader.Calls())
+
+		clock.Advance(time.Second)
+
+		_, err = cache.Get(1).Await(ctx)
+		assert.ErrorIs(t, err, io.EOF)
+		assert.Equal(t, 2, loader.Calls())
+	})
+
+	t.Run("lru eviction", func(t *testing.T) {
+		t.Parallel()
+
+		loader := &testLoader{}
+		cache := NewCache(ctx, loader.Load, CacheOptions{MaxSize: 2})
+
+		for _, key := range []int{1, 2, 1, 3} {
+			_, err := cache.Get(key).Await(ctx)
+			assert.NoError(t, err)
+		}
+
+		assert.Equal(t, 2, cache.Len())
+
+		v, err := cache.Get(1).Await(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "1/1", v)
+
+		v, err = cache.Get(2).Await(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "2/4", v)
+	})
+
+	t.Run("invalidate", func(t *testing.T) {
+		t.Parallel()
+
+		loader := &testLoader{}
+		cache := NewCache(ctx, loader.Load, CacheOptions{})
+
+		v, err := cache.Get(1).Await(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "1/1", v)
+
+		cache.Invalidate(1)
+		assert.Zero(t, cache.Len())
+
+		v, err = cache.Get(1).Await(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "1/2", v)
+	})
+}
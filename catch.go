@@ -0,0 +1,95 @@
+package async
+
+import (
+	"context"
+	"errors"
+
+	"github.com/denisss025/go-async/pipeline"
+)
+
+// Catch returns a new Future that handles the error of the current Future
+// with function handle. The value of the current Future is passed through
+// when it succeeds. Function handle also gets context.Canceled when
+// the current Future is cancelled.
+func (f *Future[T]) Catch(ctx context.Context,
+	handle func(context.Context, error) (T, error)) *Future[T] {
+	return catch(ctx, f, func(error) bool { return true }, handle)
+}
+
+// CatchIs works like Catch, but handles only the errors that match
+// the target error according to errors.Is.
+func (f *Future[T]) CatchIs(ctx context.Context, target error,
+	handle func(context.Context, error) (T, error)) *Future[T] {
+	return catch(ctx, f, func(err error) bool { return errors.Is(err, target) },
+		handle)
+}
+
+// Recover returns a new Future that is always fulfilled: either with
+// the value of the current Future or with the value that function fn
+// returns for its error.
+func (f *Future[T]) Recover(ctx context.Context,
+	fn func(context.Context, error) T) *Future[T] {
+	return f.Catch(ctx, func(ctx context.Context, err error) (T, error) {
+		return fn(ctx, err), nil
+	})
+}
+
+// Finally returns a new Future that runs function fn when the current
+// Future is settled and then passes its result through. Function fn runs
+// even when the current Future is cancelled, so it is useful for cleanups.
+func (f *Future[T]) Finally(ctx context.Context,
+	fn func(context.Context)) *Future[T] {
+	return handleWith(ctx, f,
+		func(ctx context.Context, result pipeline.Result[T]) (T, error) {
+			fn(ctx)
+
+			return result.Val, result.Err
+		})
+}
+
+// CatchAs works like Catch, but handles only the errors that can be
+// converted to type E according to errors.As.
+func CatchAs[T any, E error](ctx context.Context, f *Future[T],
+	handle func(context.Context, E) (T, error)) *Future[T] {
+	var target E
+
+	return catch(ctx, f, func(err error) bool { return errors.As(err, &target) },
+		func(ctx context.Context, _ error) (T, error) {
+			return handle(ctx, target)
+		})
+}
+
+func catch[T any](ctx context.Context, f *Future[T], match func(error) bool,
+	handle func(context.Context, error) (T, error)) *Future[T] {
+	return handleWith(ctx, f,
+		func(ctx context.Context, result pipeline.Result[T]) (T, error) {
+			if result.Err == nil || !match(result.Err) {
+				return result.Val, result.Err
+			}
+
+			return handle(ctx, result.Err)
+		})
+}
+
+// handleWith waits for the first task to be settled and always runs function
+// next with its result. Unlike continueWith, it does not follow
+// the cancellation of the first task, so that function next can handle it.
+func handleWith[T, V any](ctx context.Context, first *Future[T],
+	next func(context.Context, pipeline.Result[T]) (V, error)) *Future[V] {
+	future := newFuture[V]()
+	ctx, future.cancel = context.WithCancel(ctx)
+
+	go func(ctx context.Context, f *Future[V]) {
+		defer f.stop()
+
+		var prev pipeline.Result[T]
+
+		<-first.Done()
+
+		prev.Val, prev.Err, _ = first.TryGet()
+
+		f.resolve(run(ctx, func() (V, error) { return next(ctx, prev) }))
+	}(ctx, future)
+
+	return future
+}
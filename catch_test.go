@@ -0,0 +1,202 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"testing"
+
+	. "github.com/denisss025/go-async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatch(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	fail := func(err error) *Future[int] {
+		return Exec(ctx, func(_ context.Context) (int, error) {
+			return 0, err
+		})
+	}
+
+	t.Run("catch", func(t *testing.T) {
+		t.Parallel()
+
+		v, err := fail(io.EOF).Catch(ctx,
+			func(_ context.Context, err error) (int, error) {
+				assert.ErrorIs(t, err, io.EOF)
+
+				return 5, nil
+			}).Await(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 5, v)
+	})
+
+	t.Run("catch and fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := fail(io.EOF).Catch(ctx,
+			func(_ context.Context, err error) (int, error) {
+				return 0, io.ErrUnexpectedEOF
+			}).Await(ctx)
+
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+
+	t.Run("catch skips values", func(t *testing.T) {
+		t.Parallel()
+
+		var called bool
+
+		v, err := Exec(ctx, func(_ context.Context) (int, error) {
+			return 5, nil
+		}).Catch(ctx, func(_ context.Context, err error) (int, error) {
+			called = true
+
+			return 0, nil
+		}).Await(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 5, v)
+		assert.False(t, called)
+	})
+
+	t.Run("catch is", func(t *testing.T) {
+		t.Parallel()
+
+		handle := func(_ context.Context, _ error) (int, error) {
+			return 5, nil
+		}
+
+		v, err := fail(io.EOF).CatchIs(ctx, io.EOF, handle).Await(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, v)
+
+		_, err = fail(io.EOF).CatchIs(ctx, io.ErrClosedPipe, handle).Await(ctx)
+		assert.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("catch as", func(t *testing.T) {
+		t.Parallel()
+
+		pathErr := &fs.PathError{Op: "open", Path: "file", Err: fs.ErrNotExist}
+
+		handle := func(_ context.Context, err *fs.PathError) (int, error) {
+			assert.Equal(t, "file", err.Path)
+
+			return 5, nil
+		}
+
+		v, err := CatchAs(ctx, fail(pathErr), handle).Await(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, v)
+
+		_, err = CatchAs(ctx, fail(io.EOF), handle).Await(ctx)
+		assert.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("catch cancel", func(t *testing.T) {
+		t.Parallel()
+
+		pending := NewPromise[int]().Future()
+
+		recovered := pending.Catch(ctx,
+			func(_ context.Context, err error) (int, error) {
+				assert.ErrorIs(t, err, context.Canceled)
+
+				return 5, nil
+			})
+
+		pending.Cancel()
+
+		v, err := recovered.Await(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, v)
+	})
+
+	t.Run("then after catch", func(t *testing.T) {
+		t.Parallel()
+
+		v, err := fail(io.EOF).Recover(ctx,
+			func(_ context.Context, _ error) int {
+				return 5
+			}).Then(ctx, func(_ context.Context, i int) (int, error) {
+			return i * 2, nil
+		}).Await(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 10, v)
+	})
+}
+
+func TestFinally(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("value", func(t *testing.T) {
+		t.Parallel()
+
+		var called bool
+
+		v, err := Exec(ctx, func(_ context.Context) (int, error) {
+			return 5, nil
+		}).Finally(ctx, func(_ context.Context) {
+			called = true
+		}).Await(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 5, v)
+		assert.True(t, called)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		var called bool
+
+		_, err := Exec(ctx, func(_ context.Context) (int, error) {
+			return 0, io.EOF
+		}).Finally(ctx, func(_ context.Context) {
+			called = true
+		}).Await(ctx)
+
+		assert.ErrorIs(t, err, io.EOF)
+		assert.True(t, called)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		t.Parallel()
+
+		var called bool
+
+		pending := NewPromise[int]().Future()
+		finally := pending.Finally(ctx, func(_ context.Context) {
+			called = true
+		})
+
+		pending.Cancel()
+
+		_, err := finally.Await(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.True(t, called)
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Exec(ctx, func(_ context.Context) (int, error) {
+			return 5, nil
+		}).Finally(ctx, func(_ context.Context) {
+			panic("boom")
+		}).Await(ctx)
+
+		var panicErr *PanicError
+
+		assert.True(t, errors.As(err, &panicErr))
+	})
+}
@@ -0,0 +1,62 @@
+package async
+
+import (
+	"context"
+
+	"github.com/denisss025/go-async/pipeline"
+)
+
+// Map returns a new Future that holds the value of the current Future
+// converted by function fn. Unlike Then, it changes the type of the result
+// with an infallible function.
+func Map[T, V any](ctx context.Context, f *Future[T], fn func(T) V) *Future[V] {
+	return Then(ctx, f, func(_ context.Context, v T) (V, error) {
+		return fn(v), nil
+	})
+}
+
+// Zip returns a Future that holds a pipeline.Pair of the values of two
+// futures of possibly different types. It fails as soon as any of the futures
+// fails and cancels the other one.
+func Zip[T, V any](ctx context.Context, first *Future[T],
+	second *Future[V]) *Future[pipeline.Pair[T, V]] {
+	return Exec(ctx, func(ctx context.Context) (
+		pair pipeline.Pair[T, V], err error) {
+		firstDone, secondDone := first.Done(), second.Done()
+
+		for firstDone != nil || secondDone != nil {
+			select {
+			case <-ctx.Done():
+				return pair, ctx.Err()
+			case <-firstDone:
+				if err = first.result.Err; err != nil {
+					second.cancelPending()
+
+					return pipeline.Pair[T, V]{}, err
+				}
+
+				pair.First, firstDone = first.result.Val, nil
+			case <-secondDone:
+				if err = second.result.Err; err != nil {
+					first.cancelPending()
+
+					return pipeline.Pair[T, V]{}, err
+				}
+
+				pair.Second, secondDone = second.result.Val, nil
+			}
+		}
+
+		return pair, nil
+	})
+}
+
+// Then2 waits for two futures to be fulfilled and runs function next with
+// their values as arguments.
+func Then2[T, V, R any](ctx context.Context, first *Future[T],
+	second *Future[V], next func(context.Context, T, V) (R, error)) *Future[R] {
+	return Then(ctx, Zip(ctx, first, second),
+		func(ctx context.Context, pair pipeline.Pair[T, V]) (R, error) {
+			return next(ctx, pair.First, pair.Second)
+		})
+}
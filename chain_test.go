@@ -0,0 +1,106 @@
+package async_test
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"testing"
+	"time"
+
+	. "github.com/denisss025/go-async"
+	"github.com/denisss025/go-async/pipeline"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMap(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	s, err := Map(ctx, Exec(ctx, testValue(5, time.Millisecond)),
+		strconv.Itoa).Await(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "5", s)
+}
+
+func TestZip(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("both fulfilled", func(t *testing.T) {
+		t.Parallel()
+
+		pair, err := Zip(ctx,
+			Exec(ctx, testValue(5, 2*time.Millisecond)),
+			Exec(ctx, testValue("five", time.Millisecond)),
+		).Await(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, pipeline.Pair[int, string]{First: 5, Second: "five"}, pair)
+	})
+
+	t.Run("fail fast", func(t *testing.T) {
+		t.Parallel()
+
+		slow := Exec(ctx, testValue("five", time.Minute))
+
+		_, err := Zip(ctx,
+			Exec(ctx, testError[int](io.EOF, time.Millisecond)),
+			slow,
+		).Await(ctx)
+
+		assert.ErrorIs(t, err, io.EOF)
+
+		_, err = slow.Await(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestThen2(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("typed chain", func(t *testing.T) {
+		t.Parallel()
+
+		repeat := Then2(ctx,
+			Exec(ctx, testValue("ab", time.Millisecond)),
+			Exec(ctx, testValue(3, time.Millisecond)),
+			func(_ context.Context, s string, n int) ([]string, error) {
+				r := make([]string, n)
+
+				for i := range r {
+					r[i] = s
+				}
+
+				return r, nil
+			})
+
+		length, err := Map(ctx, repeat,
+			func(s []string) int { return len(s) }).Await(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, length)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		var called bool
+
+		_, err := Then2(ctx,
+			Exec(ctx, testValue("ab", time.Millisecond)),
+			Exec(ctx, testError[int](io.EOF, time.Millisecond)),
+			func(_ context.Context, _ string, _ int) (int, error) {
+				called = true
+
+				return 0, nil
+			}).Await(ctx)
+
+		assert.ErrorIs(t, err, io.EOF)
+		assert.False(t, called)
+	})
+}
@@ -0,0 +1,89 @@
+package async
+
+import (
+	"context"
+	"errors"
+
+	"github.com/denisss025/go-async/pipeline"
+)
+
+// ErrNoValue is the error of a Future created by FromChan when the channel
+// is closed without a value.
+var ErrNoValue = errors.New("async: channel closed without a value")
+
+// FromChan returns a Future that holds the first value of a given channel.
+// The Future is rejected with ErrNoValue when the channel is closed without
+// a value.
+func FromChan[T any](ctx context.Context, in <-chan T) *Future[T] {
+	return Exec(ctx, func(ctx context.Context) (v T, err error) {
+		select {
+		case <-ctx.Done():
+			return v, ctx.Err()
+		case v, ok := <-in:
+			if !ok {
+				return v, ErrNoValue
+			}
+
+			return v, nil
+		}
+	})
+}
+
+// ToChan returns a channel that receives the result of the Future once it
+// is settled and then is closed.
+func (f *Future[T]) ToChan() <-chan pipeline.Result[T] {
+	c := make(chan pipeline.Result[T], 1)
+
+	go func(out chan<- pipeline.Result[T], done <-chan struct{}) {
+		defer close(out)
+
+		<-done
+
+		out <- f.result
+	}(c, f.Done())
+
+	return c
+}
+
+// MapAsync runs function fn asynchronously for every value of an input
+// channel and sends the futures of these calls to an output channel in
+// the order of the input values. The output channel buffers up to window
+// futures, so that many calls may run ahead of the consumer.
+func MapAsync[T, V any](ctx context.Context, window int,
+	fn func(context.Context, T) (V, error), input <-chan T) (
+	output <-chan *Future[V]) {
+	c := make(chan *Future[V], window)
+
+	go func(ctx context.Context, out chan<- *Future[V], in <-chan T) {
+		defer close(out)
+
+		for v := range in {
+			v := v
+
+			f := Exec(ctx, func(ctx context.Context) (V, error) {
+				return fn(ctx, v)
+			})
+
+			select {
+			case <-ctx.Done():
+				f.Cancel()
+
+				return
+			case out <- f:
+			}
+		}
+	}(ctx, c, pipeline.OrDone(ctx, input))
+
+	return c
+}
+
+// AwaitChan awaits the futures of an input channel one by one and sends
+// their results to an output channel in the same order.
+func AwaitChan[T any](ctx context.Context, input <-chan *Future[T]) (
+	output <-chan pipeline.Result[T]) {
+	return pipeline.Map(ctx, func(f *Future[T]) (result pipeline.Result[T]) {
+		result.Val, result.Err = f.Await(ctx)
+
+		return result
+	}, input)
+}
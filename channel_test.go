@@ -0,0 +1,169 @@
+package async_test
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/denisss025/go-async"
+	"github.com/denisss025/go-async/pipeline"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromChan(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("first value", func(t *testing.T) {
+		t.Parallel()
+
+		v, err := FromChan(ctx, pipeline.Range(ctx, 5, 10)).Await(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 5, v)
+	})
+
+	t.Run("no value", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := FromChan(ctx, pipeline.ToChan[int](ctx)).Await(ctx)
+
+		assert.ErrorIs(t, err, ErrNoValue)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(ctx, time.Millisecond)
+
+		defer cancel()
+
+		_, err := FromChan(ctx, make(chan int)).Await(context.Background())
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestFutureToChan(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ch := Exec(ctx, testValue(5, time.Millisecond)).ToChan()
+
+	result, ok := <-ch
+	assert.True(t, ok)
+	assert.Equal(t, pipeline.Result[int]{Val: 5}, result)
+
+	_, ok = <-ch
+	assert.False(t, ok)
+
+	result = <-Exec(ctx, testError[int](io.EOF, 0)).ToChan()
+	assert.ErrorIs(t, result.Err, io.EOF)
+}
+
+func TestMapAsync(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("ordered results", func(t *testing.T) {
+		t.Parallel()
+
+		const (
+			window = 4
+			n      = 20
+		)
+
+		var running, maxRunning int32
+
+		double := func(_ context.Context, i int) (int, error) {
+			r := atomic.AddInt32(&running, 1)
+
+			defer atomic.AddInt32(&running, -1)
+
+			for {
+				m := atomic.LoadInt32(&maxRunning)
+				if r <= m || atomic.CompareAndSwapInt32(&maxRunning, m, r) {
+					break
+				}
+			}
+
+			time.Sleep(time.Duration(n-i) * 100 * time.Microsecond)
+
+			if i == 13 {
+				return 0, io.EOF
+			}
+
+			return i * 2, nil
+		}
+
+		results := AwaitChan(ctx,
+			MapAsync(ctx, window, double, pipeline.Range(ctx, 0, n)))
+
+		var i int
+
+		for result := range results {
+			if i == 13 {
+				assert.ErrorIs(t, result.Err, io.EOF)
+			} else {
+				assert.NoError(t, result.Err)
+				assert.Equal(t, i*2, result.Val)
+			}
+
+			i++
+		}
+
+		assert.Equal(t, n, i)
+		assert.Greater(t, atomic.LoadInt32(&maxRunning), int32(1))
+	})
+
+	t.Run("values", func(t *testing.T) {
+		t.Parallel()
+
+		half := func(_ context.Context, i int) (int, error) {
+			if i%2 != 0 {
+				return 0, io.EOF
+			}
+
+			return i / 2, nil
+		}
+
+		values, wait := pipeline.Values(ctx, pipeline.SkipErrors,
+			AwaitChan(ctx, MapAsync(ctx, 2, half, pipeline.Range(ctx, 0, 6))))
+
+		var out []int
+
+		for v := range values {
+			out = append(out, v)
+		}
+
+		assert.Equal(t, []int{0, 1, 2}, out)
+		assert.ErrorIs(t, wait(), io.EOF)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(ctx)
+
+		defer cancel()
+
+		futures := MapAsync(ctx, 0, func(_ context.Context, i int) (int, error) {
+			return i, nil
+		}, pipeline.Range(ctx, 0, 1000))
+
+		var n int
+
+		for range futures {
+			if n++; n == 10 {
+				cancel()
+			}
+		}
+
+		assert.Less(t, n, 1000)
+	})
+}
@@ -0,0 +1,140 @@
+package async
+
+import (
+	"context"
+	"strings"
+
+	"github.com/denisss025/go-async/pipeline"
+)
+
+// AggregateError is returned by Any when all the futures fail.
+type AggregateError struct {
+	Errors []error
+}
+
+// Error returns the messages of all the aggregated errors.
+func (e *AggregateError) Error() string {
+	msgs := make([]string, len(e.Errors))
+
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+
+	return "async: all futures failed: " + strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the aggregated errors.
+func (e *AggregateError) Unwrap() []error {
+	return e.Errors
+}
+
+// settled returns a channel that receives indices of the given futures
+// in the order they are settled.
+func settled[T any](ctx context.Context, futures []*Future[T]) <-chan int {
+	c := make(chan int, len(futures))
+
+	for i := range futures {
+		go func(ctx context.Context, i int, f *Future[T], out chan<- int) {
+			select {
+			case <-ctx.Done():
+			case <-f.Done():
+				out <- i
+			}
+		}(ctx, i, futures[i], c)
+	}
+
+	return c
+}
+
+// All returns a Future that is fulfilled with the values of all the given
+// futures in the same order. It fails as soon as any of the futures fails
+// and cancels the rest of them.
+func All[T any](ctx context.Context, futures ...*Future[T]) *Future[[]T] {
+	return Exec(ctx, func(ctx context.Context) ([]T, error) {
+		vals := make([]T, len(futures))
+		ready := settled(ctx, futures)
+
+		for range futures {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case i := <-ready:
+				result := futures[i].result
+				if result.Err != nil {
+					for _, f := range futures {
+						f.cancelPending()
+					}
+
+					return nil, result.Err
+				}
+
+				vals[i] = result.Val
+			}
+		}
+
+		return vals, nil
+	})
+}
+
+// AllSettled returns a Future that is fulfilled with the results of all
+// the given futures in the same order when all of them are settled.
+func AllSettled[T any](ctx context.Context, futures ...*Future[T]) (
+	future *Future[[]pipeline.Result[T]]) {
+	return Exec(ctx, func(ctx context.Context) ([]pipeline.Result[T], error) {
+		results := make([]pipeline.Result[T], len(futures))
+		ready := settled(ctx, futures)
+
+		for range futures {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case i := <-ready:
+				results[i] = futures[i].result
+			}
+		}
+
+		return results, nil
+	})
+}
+
+// Any returns a Future that is fulfilled with the value of the first
+// fulfilled future. When all the futures fail it is rejected with
+// an AggregateError that holds their errors in the same order.
+func Any[T any](ctx context.Context, futures ...*Future[T]) *Future[T] {
+	return Exec(ctx, func(ctx context.Context) (v T, err error) {
+		errs := make([]error, len(futures))
+		ready := settled(ctx, futures)
+
+		for range futures {
+			select {
+			case <-ctx.Done():
+				return v, ctx.Err()
+			case i := <-ready:
+				result := futures[i].result
+				if result.Err == nil {
+					return result.Val, nil
+				}
+
+				errs[i] = result.Err
+			}
+		}
+
+		return v, &AggregateError{Errors: errs}
+	})
+}
+
+// Race returns a Future that is settled with the result of the first
+// settled future. With no futures it is settled only when the context
+// is done.
+func Race[T any](ctx context.Context, futures ...*Future[T]) *Future[T] {
+	return Exec(ctx, func(ctx context.Context) (v T, err error) {
+		select {
+		case <-ctx.Done():
+			return v, ctx.Err()
+		case i := <-settled(ctx, futures):
+			result := futures[i].result
+
+			return result.Val, result.Err
+		}
+	})
+}
@@ -0,0 +1,178 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	. "github.com/denisss025/go-async"
+	"github.com/denisss025/go-async/pipeline"
+	"github.com/stretchr/testify/assert"
+)
+
+func testValue[T any](v T, d time.Duration) func(context.Context) (T, error) {
+	return func(ctx context.Context) (zero T, err error) {
+		select {
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		case <-time.After(d):
+			return v, nil
+		}
+	}
+}
+
+func testError[T any](err error, d time.Duration) func(context.Context) (T, error) {
+	return func(ctx context.Context) (zero T, _ error) {
+		select {
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		case <-time.After(d):
+			return zero, err
+		}
+	}
+}
+
+func TestAll(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("all fulfilled", func(t *testing.T) {
+		t.Parallel()
+
+		nums, err := All(ctx,
+			Exec(ctx, testValue(1, 3*time.Millisecond)),
+			Exec(ctx, testValue(2, time.Millisecond)),
+			Exec(ctx, testValue(3, 2*time.Millisecond)),
+		).Await(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3}, nums)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		nums, err := All[int](ctx).Await(ctx)
+
+		assert.NoError(t, err)
+		assert.Empty(t, nums)
+	})
+
+	t.Run("fail fast", func(t *testing.T) {
+		t.Parallel()
+
+		slow := Exec(ctx, testValue(1, time.Minute))
+
+		nums, err := All(ctx, slow,
+			Exec(ctx, testError[int](io.EOF, time.Millisecond)),
+		).Await(ctx)
+
+		assert.ErrorIs(t, err, io.EOF)
+		assert.Nil(t, nums)
+
+		_, err = slow.Await(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(ctx, time.Millisecond)
+
+		defer cancel()
+
+		_, err := All(ctx, Exec(context.Background(),
+			testValue(1, time.Second))).Await(context.Background())
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestAllSettled(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	results, err := AllSettled(ctx,
+		Exec(ctx, testValue(1, 2*time.Millisecond)),
+		Exec(ctx, testError[int](io.EOF, time.Millisecond)),
+	).Await(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []pipeline.Result[int]{{Val: 1}, {Err: io.EOF}}, results)
+}
+
+func TestAny(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("first fulfilled", func(t *testing.T) {
+		t.Parallel()
+
+		v, err := Any(ctx,
+			Exec(ctx, testError[int](io.EOF, time.Millisecond)),
+			Exec(ctx, testValue(2, 2*time.Millisecond)),
+			Exec(ctx, testValue(3, time.Second)),
+		).Await(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, v)
+	})
+
+	t.Run("all failed", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Any(ctx,
+			Exec(ctx, testError[int](io.EOF, 2*time.Millisecond)),
+			Exec(ctx, testError[int](io.ErrClosedPipe, time.Millisecond)),
+		).Await(ctx)
+
+		var aggErr *AggregateError
+
+		assert.True(t, errors.As(err, &aggErr))
+		assert.Equal(t, []error{io.EOF, io.ErrClosedPipe}, aggErr.Errors)
+	})
+}
+
+func TestRace(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("first fulfilled", func(t *testing.T) {
+		t.Parallel()
+
+		v, err := Race(ctx,
+			Exec(ctx, testValue(1, time.Second)),
+			Exec(ctx, testValue(2, time.Millisecond)),
+		).Await(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, v)
+	})
+
+	t.Run("first rejected", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Race(ctx,
+			Exec(ctx, testValue(1, time.Second)),
+			Exec(ctx, testError[int](io.EOF, time.Millisecond)),
+		).Await(ctx)
+
+		assert.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(ctx, time.Millisecond)
+
+		defer cancel()
+
+		_, err := Race[int](ctx).Await(context.Background())
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
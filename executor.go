@@ -0,0 +1,170 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/denisss025/go-async/pipeline"
+)
+
+var (
+	// ErrRejected is returned when an Executor rejects a task.
+	ErrRejected = errors.New("async: task rejected")
+	// ErrExecutorClosed is returned when a task is submitted to a closed
+	// Executor.
+	ErrExecutorClosed = errors.New("async: executor is closed")
+)
+
+// Executor runs tasks.
+type Executor interface {
+	// Submit schedules the task for execution. It returns an error when
+	// the task cannot be scheduled.
+	Submit(ctx context.Context, task func()) error
+}
+
+// GoExecutor runs every task in a new goroutine. It is the default Executor
+// of Exec.
+type GoExecutor struct{}
+
+// Submit runs the task in a new goroutine.
+func (GoExecutor) Submit(_ context.Context, task func()) error {
+	go task()
+
+	return nil
+}
+
+// QueuePolicy defines how a WorkerPool handles a task when its queue is full.
+type QueuePolicy int
+
+// Queue policies of a WorkerPool.
+const (
+	// Block blocks Submit until the task is queued or the context is done.
+	Block QueuePolicy = iota
+	// Reject makes Submit return ErrRejected.
+	Reject
+	// CallerRuns runs the task in the goroutine that calls Submit.
+	CallerRuns
+)
+
+// WorkerPool is an Executor that runs tasks on a bounded number of
+// goroutines.
+type WorkerPool struct {
+	mu     sync.RWMutex
+	wg     sync.WaitGroup
+	closed bool
+	queue  chan func()
+	slots  chan struct{}
+	policy QueuePolicy
+}
+
+// NewWorkerPool starts a WorkerPool with the given number of workers and
+// the given size of the task queue. Panics when the number of workers is
+// less than 1.
+func NewWorkerPool(maxWorkers, queueSize int, policy QueuePolicy) *WorkerPool {
+	if maxWorkers < 1 {
+		panic("number of workers must be greater than 0")
+	}
+
+	if queueSize < 0 {
+		queueSize = 0
+	}
+
+	// A slot is taken by every task that is running or queued, so
+	// the queue has room for every task that has got a slot.
+	p := &WorkerPool{
+		queue:  make(chan func(), maxWorkers+queueSize),
+		slots:  make(chan struct{}, maxWorkers+queueSize),
+		policy: policy,
+	}
+
+	p.wg.Add(maxWorkers)
+
+	for i := 0; i < maxWorkers; i++ {
+		go func(wg *sync.WaitGroup, in <-chan func(), slots <-chan struct{}) {
+			defer wg.Done()
+
+			for task := range in {
+				task()
+				<-slots
+			}
+		}(&p.wg, p.queue, p.slots)
+	}
+
+	return p
+}
+
+// Submit queues the task. When the queue is full the task is handled
+// according to the QueuePolicy of the WorkerPool.
+func (p *WorkerPool) Submit(ctx context.Context, task func()) error {
+	p.mu.RLock()
+	queued, err := p.enqueue(ctx, task)
+	p.mu.RUnlock()
+
+	if !queued && err == nil {
+		task()
+	}
+
+	return err
+}
+
+// enqueue queues the task when there is a free worker or room in the queue.
+// It returns false and no error when the task must run in the goroutine of
+// the caller.
+func (p *WorkerPool) enqueue(ctx context.Context, task func()) (bool, error) {
+	if p.closed {
+		return false, ErrExecutorClosed
+	}
+
+	select {
+	case p.slots <- struct{}{}:
+	default:
+		switch p.policy {
+		case Reject:
+			return false, ErrRejected
+		case CallerRuns:
+			return false, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case p.slots <- struct{}{}:
+		}
+	}
+
+	p.queue <- task
+
+	return true, nil
+}
+
+// Close stops accepting new tasks and waits for the queued tasks to finish.
+func (p *WorkerPool) Close() {
+	p.mu.Lock()
+
+	if !p.closed {
+		p.closed = true
+
+		close(p.queue)
+	}
+
+	p.mu.Unlock()
+
+	p.wg.Wait()
+}
+
+// ExecOn runs function fn on the given Executor and returns a Future that
+// will eventually hold the result of that function call. The Future is
+// rejected when the Executor fails to schedule the task.
+func ExecOn[T any](ctx context.Context, executor Executor,
+	fn func(context.Context) (T, error)) (future *Future[T]) {
+	future = newFuture[T]()
+	ctx, future.cancel = context.WithCancel(ctx)
+
+	if err := executor.Submit(ctx, future.task(ctx, fn)); err != nil {
+		future.resolve(pipeline.Result[T]{Err: err})
+		future.stop()
+	}
+
+	return future
+}
@@ -0,0 +1,194 @@
+package async_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/denisss025/go-async"
+	"github.com/stretchr/testify/assert"
+)
+
+func testBusyPool(t *testing.T, policy QueuePolicy) (
+	pool *WorkerPool, release func()) {
+	t.Helper()
+
+	pool = NewWorkerPool(1, 1, policy)
+
+	started, stop := make(chan struct{}), make(chan struct{})
+
+	_ = ExecOn(context.Background(), pool,
+		func(_ context.Context) (int, error) {
+			close(started)
+			<-stop
+
+			return 0, nil
+		})
+
+	<-started
+
+	// The worker is busy, so the task fills the queue.
+	_ = ExecOn(context.Background(), pool, testValue(0, 0))
+
+	return pool, func() {
+		close(stop)
+		pool.Close()
+	}
+}
+
+func TestWorkerPool(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("bounded", func(t *testing.T) {
+		t.Parallel()
+
+		const (
+			workers = 2
+			tasks   = 10
+		)
+
+		pool := NewWorkerPool(workers, tasks, Block)
+
+		defer pool.Close()
+
+		var running, maxRunning int32
+
+		futures := make([]*Future[int], tasks)
+
+		for i := range futures {
+			i := i
+
+			futures[i] = ExecOn(ctx, pool, func(_ context.Context) (int, error) {
+				n := atomic.AddInt32(&running, 1)
+
+				defer atomic.AddInt32(&running, -1)
+
+				for {
+					m := atomic.LoadInt32(&maxRunning)
+					if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+						break
+					}
+				}
+
+				time.Sleep(time.Millisecond)
+
+				return i, nil
+			})
+		}
+
+		for i, f := range futures {
+			v, err := f.Await(ctx)
+
+			assert.NoError(t, err)
+			assert.Equal(t, i, v)
+		}
+
+		assert.LessOrEqual(t, atomic.LoadInt32(&maxRunning), int32(workers))
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		t.Parallel()
+
+		pool, release := testBusyPool(t, Reject)
+
+		defer release()
+
+		_, err := ExecOn(ctx, pool, testValue(5, 0)).Await(ctx)
+		assert.ErrorIs(t, err, ErrRejected)
+	})
+
+	t.Run("caller runs", func(t *testing.T) {
+		t.Parallel()
+
+		pool, release := testBusyPool(t, CallerRuns)
+
+		defer release()
+
+		f := ExecOn(ctx, pool, testValue(5, 0))
+
+		five, err, ok := f.TryGet()
+		assert.True(t, ok)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, five)
+	})
+
+	t.Run("block", func(t *testing.T) {
+		t.Parallel()
+
+		pool, release := testBusyPool(t, Block)
+
+		defer release()
+
+		timeout, cancel := context.WithTimeout(ctx, time.Millisecond)
+
+		defer cancel()
+
+		_, err := ExecOn(timeout, pool, testValue(5, 0)).Await(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("no queue", func(t *testing.T) {
+		t.Parallel()
+
+		pool := NewWorkerPool(1, 0, Reject)
+
+		defer pool.Close()
+
+		started, stop := make(chan struct{}), make(chan struct{})
+
+		busy := ExecOn(ctx, pool, func(_ context.Context) (int, error) {
+			close(started)
+			<-stop
+
+			return 0, nil
+		})
+
+		// The idle worker accepts the task without a queue.
+		_, _, rejected := busy.TryGet()
+		assert.False(t, rejected)
+
+		<-started
+
+		_, err := ExecOn(ctx, pool, testValue(5, 0)).Await(ctx)
+		assert.ErrorIs(t, err, ErrRejected)
+
+		close(stop)
+
+		_, err = busy.Await(ctx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		t.Parallel()
+
+		pool := NewWorkerPool(1, 1, Block)
+		pool.Close()
+
+		_, err := ExecOn(ctx, pool, testValue(5, 0)).Await(ctx)
+		assert.ErrorIs(t, err, ErrExecutorClosed)
+	})
+
+	t.Run("close waits for queued tasks", func(t *testing.T) {
+		t.Parallel()
+
+		pool := NewWorkerPool(1, 5, Block)
+		futures := make([]*Future[int], 5)
+
+		for i := range futures {
+			futures[i] = ExecOn(ctx, pool, testValue(i, time.Millisecond))
+		}
+
+		pool.Close()
+
+		for i, f := range futures {
+			v, err, ok := f.TryGet()
+
+			assert.True(t, ok)
+			assert.NoError(t, err)
+			assert.Equal(t, i, v)
+		}
+	})
+}
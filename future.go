@@ -2,32 +2,219 @@ package async
 
 import (
 	"context"
+	"errors"
+	"sync"
 
 	"github.com/denisss025/go-async/pipeline"
 )
 
-type futureResult[T any] struct {
-	Val T
-	Err error
+// State describes the state of a Future.
+type State int
+
+// States of a Future.
+const (
+	// Pending means that the Future is not settled yet.
+	Pending State = iota
+	// Fulfilled means that the Future holds a value.
+	Fulfilled
+	// Rejected means that the Future holds an error.
+	Rejected
+	// Cancelled means that the Future holds context.Canceled error.
+	Cancelled
+)
+
+// String returns the name of the state.
+func (s State) String() string {
+	switch s {
+	case Pending:
+		return "pending"
+	case Fulfilled:
+		return "fulfilled"
+	case Rejected:
+		return "rejected"
+	case Cancelled:
+		return "cancelled"
+	default:
+		return "unknown"
+	}
 }
 
 // Future provides a mechanism to access the result of asynchronous operations.
+// The result is memoized, so a Future can be awaited any number of times and
+// by any number of goroutines.
 type Future[T any] struct {
-	result <-chan futureResult[T]
+	done   chan struct{}
+	once   sync.Once
+	result pipeline.Result[T]
+	cancel context.CancelFunc
+
+	mu        sync.Mutex
+	cancelled bool
+	children  map[int]func()
+	nextChild int
+
+	start     func()
+	startOnce sync.Once
+}
+
+func newFuture[T any]() *Future[T] {
+	return &Future[T]{done: make(chan struct{})}
+}
+
+// resolve settles the Future with the given result. It returns false when
+// the Future has already been settled.
+func (f *Future[T]) resolve(result pipeline.Result[T]) (ok bool) {
+	f.once.Do(func() {
+		f.result, ok = result, true
+
+		close(f.done)
+	})
+
+	return ok
+}
+
+// task returns a function that calls fn and settles the Future with its
+// result. Function fn is not called when the context is already done.
+func (f *Future[T]) task(ctx context.Context,
+	fn func(context.Context) (T, error)) func() {
+	return func() {
+		defer f.stop()
+
+		var result pipeline.Result[T]
+
+		if result.Err = ctx.Err(); result.Err == nil {
+			result = run(ctx, func() (T, error) { return fn(ctx) })
+		}
+
+		f.resolve(result)
+	}
+}
+
+// stop cancels the context of the task that settles the Future, if any.
+func (f *Future[T]) stop() {
+	if f.cancel != nil {
+		f.cancel()
+	}
+}
+
+// Cancel rejects the Future with context.Canceled, cancels the context of
+// its task and cancels all the pending downstream continuations. Futures
+// that do not depend on the Future are not affected. Cancel does nothing
+// when the Future is already settled.
+func (f *Future[T]) Cancel() {
+	if !f.resolve(pipeline.Result[T]{Err: context.Canceled}) {
+		return
+	}
+
+	f.stop()
+
+	f.mu.Lock()
+	children := f.children
+	f.children, f.cancelled = nil, true
+	f.mu.Unlock()
+
+	for _, cancel := range children {
+		cancel()
+	}
+}
+
+// cancelPending cancels the Future unless it is already settled.
+func (f *Future[T]) cancelPending() {
+	select {
+	case <-f.done:
+	default:
+		f.Cancel()
+	}
+}
+
+// onCancel registers function fn to be called when the Future is cancelled.
+// Function fn is called immediately when the Future is already cancelled.
+// The returned function removes the registration.
+func (f *Future[T]) onCancel(fn func()) (remove func()) {
+	f.mu.Lock()
+
+	if !f.cancelled {
+		if f.children == nil {
+			f.children = make(map[int]func())
+		}
+
+		id := f.nextChild
+		f.children[id] = fn
+		f.nextChild++
+
+		f.mu.Unlock()
+
+		return func() {
+			f.mu.Lock()
+			delete(f.children, id)
+			f.mu.Unlock()
+		}
+	}
+
+	f.mu.Unlock()
+
+	fn()
+
+	return func() {}
 }
 
-// Await returns the result of the asynchronous operation.
+// Await returns the result of the asynchronous operation. It returns
+// the context error when the context is done before the result is ready.
+// It starts a lazy Future.
 func (f *Future[T]) Await(ctx context.Context) (v T, err error) {
+	if err = ctx.Err(); err != nil {
+		return v, err
+	}
+
+	f.Start()
+
 	select {
 	case <-ctx.Done():
 		err = ctx.Err()
-	case result := <-f.result:
-		v, err = result.Val, result.Err
+	case <-f.done:
+		v, err = f.result.Val, f.result.Err
 	}
 
 	return v, err
 }
 
+// Done returns a channel that is closed when the Future is settled.
+// It starts a lazy Future.
+func (f *Future[T]) Done() <-chan struct{} {
+	f.Start()
+
+	return f.done
+}
+
+// TryGet returns the result of the asynchronous operation without blocking.
+// It returns false when the Future is not settled yet.
+func (f *Future[T]) TryGet() (v T, err error, ok bool) {
+	select {
+	case <-f.done:
+		return f.result.Val, f.result.Err, true
+	default:
+		return v, nil, false
+	}
+}
+
+// State returns the current state of the Future.
+func (f *Future[T]) State() State {
+	select {
+	case <-f.done:
+	default:
+		return Pending
+	}
+
+	switch err := f.result.Err; {
+	case err == nil:
+		return Fulfilled
+	case errors.Is(err, context.Canceled):
+		return Cancelled
+	default:
+		return Rejected
+	}
+}
+
 // Then returns a new Future that waits for the result of the asynchronous
 // operation of the current Future and asynchronously handles the result
 // of the next function.
@@ -37,31 +224,56 @@ func (f *Future[T]) Then(ctx context.Context,
 }
 
 // Exec runs function fn asynchronously and returns a Future that will
-// eventually hold the result of that function call.
+// eventually hold the result of that function call. A panic inside fn is
+// recovered and returned as a PanicError unless the context is made
+// with CrashOnPanic. Every call of Exec runs fn in a new goroutine, use
+// ExecOn to limit the number of goroutines.
 func Exec[T any](ctx context.Context, fn func(context.Context) (T, error)) (
 	future *Future[T]) {
-	return &Future[T]{
-		result: pipeline.Map(ctx,
-			func(ctx context.Context) (v futureResult[T]) {
-				v.Val, v.Err = fn(ctx)
-
-				return v
-			}, pipeline.ToChan(ctx, ctx)),
-	}
+	return ExecOn(ctx, GoExecutor{}, fn)
 }
 
 // Then waits for the first task to be done and runs function next with the
 // result of the first task as an argument.
 func Then[T, V any](ctx context.Context, first *Future[T],
 	next func(context.Context, T) (V, error)) *Future[V] {
-	return &Future[V]{
-		result: pipeline.Map(ctx,
-			func(t futureResult[T]) (v futureResult[V]) {
-				if v.Err = t.Err; v.Err == nil {
-					v.Val, v.Err = next(ctx, t.Val)
-				}
-
-				return v
-			}, first.result),
-	}
+	return continueWith(ctx, first,
+		func(ctx context.Context, result pipeline.Result[T]) (v V, err error) {
+			if result.Err != nil {
+				return v, result.Err
+			}
+
+			return next(ctx, result.Val)
+		})
+}
+
+// continueWith waits for the first task to be done and runs function next
+// with the result of the first task. Function next is not called when
+// the context is done before the first task.
+func continueWith[T, V any](ctx context.Context, first *Future[T],
+	next func(context.Context, pipeline.Result[T]) (V, error)) *Future[V] {
+	future := newFuture[V]()
+	ctx, future.cancel = context.WithCancel(ctx)
+
+	remove := first.onCancel(future.Cancel)
+
+	go func(ctx context.Context, f *Future[V]) {
+		defer remove()
+		defer f.stop()
+
+		var (
+			result pipeline.Result[V]
+			prev   pipeline.Result[T]
+		)
+
+		if prev.Val, prev.Err = first.Await(ctx); ctx.Err() != nil {
+			result.Err = ctx.Err()
+		} else {
+			result = run(ctx, func() (V, error) { return next(ctx, prev) })
+		}
+
+		f.resolve(result)
+	}(ctx, future)
+
+	return future
 }
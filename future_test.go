@@ -3,6 +3,8 @@ package async_test
 import (
 	"context"
 	"io"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -112,4 +114,341 @@ func TestThen(t *testing.T) {
 		assert.Zero(t, zero)
 		assert.False(t, called)
 	})
+
+	t.Run("several continuations", func(t *testing.T) {
+		t.Parallel()
+
+		getFive := Exec(ctx, func(_ context.Context) (int, error) {
+			return 5, nil
+		})
+
+		double := getFive.Then(ctx, func(_ context.Context, i int) (int, error) {
+			return i * 2, nil
+		})
+
+		square := getFive.Then(ctx, func(_ context.Context, i int) (int, error) {
+			return i * i, nil
+		})
+
+		ten, err := double.Await(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 10, ten)
+
+		twentyFive, err := square.Await(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 25, twentyFive)
+	})
+}
+
+func TestAwait(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("await twice", func(t *testing.T) {
+		t.Parallel()
+
+		getFive := Exec(ctx, func(_ context.Context) (int, error) {
+			return 5, nil
+		})
+
+		for i := 0; i < 2; i++ {
+			five, err := getFive.Await(ctx)
+
+			assert.NoError(t, err)
+			assert.Equal(t, 5, five)
+		}
+	})
+
+	t.Run("concurrent awaiters", func(t *testing.T) {
+		t.Parallel()
+
+		const awaiters = 10
+
+		var calls int32
+
+		start := make(chan struct{})
+
+		getFive := Exec(ctx, func(_ context.Context) (int, error) {
+			<-start
+			atomic.AddInt32(&calls, 1)
+
+			return 5, nil
+		})
+
+		results := make([]int, awaiters)
+		errs := make([]error, awaiters)
+
+		wg := new(sync.WaitGroup)
+		wg.Add(awaiters)
+
+		for i := 0; i < awaiters; i++ {
+			go func(i int) {
+				defer wg.Done()
+
+				results[i], errs[i] = getFive.Await(ctx)
+			}(i)
+		}
+
+		close(start)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+		for i := range results {
+			assert.NoError(t, errs[i])
+			assert.Equal(t, 5, results[i])
+		}
+	})
+
+	t.Run("error is memoized", func(t *testing.T) {
+		t.Parallel()
+
+		fail := Exec(ctx, func(_ context.Context) (int, error) {
+			return 0, io.EOF
+		})
+
+		for i := 0; i < 2; i++ {
+			_, err := fail.Await(ctx)
+
+			assert.ErrorIs(t, err, io.EOF)
+		}
+	})
+
+	t.Run("await timeout does not consume", func(t *testing.T) {
+		t.Parallel()
+
+		start := make(chan struct{})
+
+		getFive := Exec(ctx, func(_ context.Context) (int, error) {
+			<-start
+
+			return 5, nil
+		})
+
+		timeout, cancel := context.WithTimeout(ctx, time.Millisecond)
+
+		defer cancel()
+
+		_, err := getFive.Await(timeout)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		close(start)
+
+		five, err := getFive.Await(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, five)
+	})
+}
+
+func TestInspect(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("pending and fulfilled", func(t *testing.T) {
+		t.Parallel()
+
+		p := NewPromise[int]()
+		f := p.Future()
+
+		_, _, ok := f.TryGet()
+		assert.False(t, ok)
+		assert.Equal(t, Pending, f.State())
+
+		select {
+		case <-f.Done():
+			assert.Fail(t, "future must not be done")
+		default:
+		}
+
+		p.Resolve(5)
+
+		<-f.Done()
+
+		five, err, ok := f.TryGet()
+		assert.True(t, ok)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, five)
+		assert.Equal(t, Fulfilled, f.State())
+
+		five, err = f.Await(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, five)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		t.Parallel()
+
+		f := Exec(ctx, func(_ context.Context) (int, error) {
+			return 0, io.EOF
+		})
+
+		<-f.Done()
+
+		_, err, ok := f.TryGet()
+		assert.True(t, ok)
+		assert.ErrorIs(t, err, io.EOF)
+		assert.Equal(t, Rejected, f.State())
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(ctx)
+
+		f := Exec(ctx, func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+
+			return 0, ctx.Err()
+		})
+
+		cancel()
+
+		<-f.Done()
+
+		assert.Equal(t, Cancelled, f.State())
+		assert.Equal(t, "cancelled", f.State().String())
+	})
+}
+
+func TestCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	waitCancel := func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+
+		return 0, ctx.Err()
+	}
+
+	t.Run("cancel task", func(t *testing.T) {
+		t.Parallel()
+
+		started, cancelled := make(chan struct{}), make(chan error, 1)
+
+		f := Exec(ctx, func(ctx context.Context) (int, error) {
+			close(started)
+			<-ctx.Done()
+			cancelled <- ctx.Err()
+
+			return 5, nil
+		})
+
+		sibling := Exec(ctx, testValue(5, time.Millisecond))
+
+		<-started
+		f.Cancel()
+
+		_, err := f.Await(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, Cancelled, f.State())
+		assert.ErrorIs(t, <-cancelled, context.Canceled)
+
+		five, err := sibling.Await(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, five)
+	})
+
+	t.Run("cancel continuations", func(t *testing.T) {
+		t.Parallel()
+
+		var called bool
+
+		first := NewPromise[int]().Future()
+		next := first.Then(ctx, func(_ context.Context, i int) (int, error) {
+			called = true
+
+			return i, nil
+		})
+		last := next.Then(ctx, func(_ context.Context, i int) (int, error) {
+			return i, nil
+		})
+
+		first.Cancel()
+
+		_, err := next.Await(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		_, err = last.Await(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		assert.Equal(t, Cancelled, first.State())
+		assert.False(t, called)
+	})
+
+	t.Run("cancel does not affect upstream and siblings", func(t *testing.T) {
+		t.Parallel()
+
+		p := NewPromise[int]()
+
+		double := p.Future().Then(ctx, func(_ context.Context, i int) (int, error) {
+			return i * 2, nil
+		})
+		square := p.Future().Then(ctx, func(_ context.Context, i int) (int, error) {
+			return i * i, nil
+		})
+
+		double.Cancel()
+		p.Resolve(5)
+
+		_, err := double.Await(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		v, err := square.Await(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 25, v)
+
+		v, err = p.Future().Await(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, v)
+	})
+
+	t.Run("settled", func(t *testing.T) {
+		t.Parallel()
+
+		p := NewPromise[int]()
+		started, release := make(chan struct{}), make(chan struct{})
+
+		double := p.Future().Then(ctx, func(ctx context.Context, i int) (int, error) {
+			close(started)
+			<-release
+
+			return i * 2, ctx.Err()
+		})
+
+		p.Resolve(5)
+		<-started
+
+		p.Future().Cancel()
+		close(release)
+
+		assert.Equal(t, Fulfilled, p.Future().State())
+
+		v, err := double.Await(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 10, v)
+	})
+
+	t.Run("continue cancelled", func(t *testing.T) {
+		t.Parallel()
+
+		var called bool
+
+		f := Exec(ctx, waitCancel)
+		f.Cancel()
+
+		v, err := f.Recover(ctx, func(_ context.Context, err error) int {
+			assert.ErrorIs(t, err, context.Canceled)
+
+			called = true
+
+			return 5
+		}).Await(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 5, v)
+		assert.True(t, called)
+	})
 }
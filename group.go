@@ -0,0 +1,123 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrGroupClosed is the error of the futures that are started in a Group
+// after its Wait method returns.
+var ErrGroupClosed = errors.New("async: group is closed")
+
+// Group is a scope that owns child futures. The first failed child cancels
+// the context of the Group, and Wait waits for all the children to be done.
+type Group struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu     sync.Mutex
+	cond   *sync.Cond
+	active int
+	closed bool
+	err    error
+}
+
+// NewGroup creates a new Group with a context derived from the given one.
+func NewGroup(ctx context.Context) *Group {
+	g := &Group{}
+	g.ctx, g.cancel = context.WithCancel(ctx)
+	g.cond = sync.NewCond(&g.mu)
+
+	return g
+}
+
+// Context returns the context of the Group. It is done when any child
+// fails or when Wait returns.
+func (g *Group) Context() context.Context {
+	return g.ctx
+}
+
+// Wait blocks until all the children are done, closes the Group and
+// returns the first error of its children.
+func (g *Group) Wait() error {
+	g.mu.Lock()
+
+	for g.active > 0 {
+		g.cond.Wait()
+	}
+
+	g.closed = true
+	err := g.err
+
+	g.mu.Unlock()
+
+	g.cancel()
+
+	return err
+}
+
+func (g *Group) submit(_ context.Context, task func()) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.closed {
+		return ErrGroupClosed
+	}
+
+	g.active++
+
+	go func() {
+		defer g.release()
+
+		task()
+	}()
+
+	return nil
+}
+
+func (g *Group) release() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.active--; g.active == 0 {
+		g.cond.Broadcast()
+	}
+}
+
+func (g *Group) fail(err error) {
+	g.mu.Lock()
+
+	if g.err == nil {
+		g.err = err
+	}
+
+	g.mu.Unlock()
+
+	g.cancel()
+}
+
+type executorFunc func(context.Context, func()) error
+
+func (fn executorFunc) Submit(ctx context.Context, task func()) error {
+	return fn(ctx, task)
+}
+
+// Go runs function fn in a new goroutine of the Group and returns a Future
+// that will eventually hold the result of that function call. Function fn
+// gets a context derived from the context of the Group. Cancelling the Future
+// does not affect the Group and the other children. When Wait has already
+// returned, the Future is rejected with ErrGroupClosed.
+func Go[T any](g *Group, fn func(context.Context) (T, error)) *Future[T] {
+	return ExecOn(g.ctx, executorFunc(g.submit),
+		func(ctx context.Context) (T, error) {
+			result := run(ctx, func() (T, error) { return fn(ctx) })
+
+			// A child cancelled by its holder does not fail the Group.
+			if result.Err != nil && (ctx.Err() == nil || g.ctx.Err() != nil) {
+				g.fail(result.Err)
+			}
+
+			return result.Val, result.Err
+		})
+}
@@ -0,0 +1,138 @@
+package async_test
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/denisss025/go-async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("wait all", func(t *testing.T) {
+		t.Parallel()
+
+		g := NewGroup(ctx)
+
+		one := Go(g, testValue(1, 2*time.Millisecond))
+		two := Go(g, testValue("two", time.Millisecond))
+
+		assert.NoError(t, g.Wait())
+
+		v, err, ok := one.TryGet()
+		assert.True(t, ok)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, v)
+
+		s, err, ok := two.TryGet()
+		assert.True(t, ok)
+		assert.NoError(t, err)
+		assert.Equal(t, "two", s)
+
+		assert.ErrorIs(t, g.Context().Err(), context.Canceled)
+	})
+
+	t.Run("first failure cancels the scope", func(t *testing.T) {
+		t.Parallel()
+
+		g := NewGroup(ctx)
+
+		slow := Go(g, testValue(1, time.Minute))
+		_ = Go(g, testError[int](io.EOF, time.Millisecond))
+
+		assert.ErrorIs(t, g.Wait(), io.EOF)
+
+		_, err, ok := slow.TryGet()
+		assert.True(t, ok)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("cancel child", func(t *testing.T) {
+		t.Parallel()
+
+		g := NewGroup(ctx)
+		started := make(chan struct{})
+
+		cancelled := Go(g, func(ctx context.Context) (int, error) {
+			close(started)
+			<-ctx.Done()
+
+			return 0, ctx.Err()
+		})
+		sibling := Go(g, testValue(2, time.Millisecond))
+
+		<-started
+		cancelled.Cancel()
+
+		assert.NoError(t, g.Wait())
+
+		_, err := cancelled.Await(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		v, err, ok := sibling.TryGet()
+		assert.True(t, ok)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, v)
+	})
+
+	t.Run("nested children", func(t *testing.T) {
+		t.Parallel()
+
+		var done int32
+
+		g := NewGroup(ctx)
+
+		_ = Go(g, func(ctx context.Context) (int, error) {
+			_ = Go(g, func(ctx context.Context) (int, error) {
+				time.Sleep(time.Millisecond)
+				atomic.AddInt32(&done, 1)
+
+				return 0, nil
+			})
+
+			return 0, nil
+		})
+
+		assert.NoError(t, g.Wait())
+		assert.Equal(t, int32(1), atomic.LoadInt32(&done))
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		t.Parallel()
+
+		var called bool
+
+		g := NewGroup(ctx)
+		assert.NoError(t, g.Wait())
+
+		_, err := Go(g, func(_ context.Context) (int, error) {
+			called = true
+
+			return 0, nil
+		}).Await(ctx)
+
+		assert.ErrorIs(t, err, ErrGroupClosed)
+		assert.False(t, called)
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		t.Parallel()
+
+		g := NewGroup(ctx)
+
+		_ = Go(g, func(_ context.Context) (int, error) {
+			panic("boom")
+		})
+
+		var panicErr *PanicError
+
+		assert.ErrorAs(t, g.Wait(), &panicErr)
+	})
+}
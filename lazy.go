@@ -0,0 +1,25 @@
+package async
+
+import "context"
+
+// Lazy returns a Future that runs function fn only when the Future is
+// awaited, continued or started for the first time. Function fn runs
+// exactly once and its result is shared by all the awaiters.
+func Lazy[T any](ctx context.Context, fn func(context.Context) (T, error)) (
+	future *Future[T]) {
+	future = newFuture[T]()
+	ctx, future.cancel = context.WithCancel(ctx)
+	future.start = future.task(ctx, fn)
+
+	return future
+}
+
+// Start runs the task of a lazy Future in a new goroutine. It does nothing
+// when the Future is not lazy or is already started.
+func (f *Future[T]) Start() {
+	f.startOnce.Do(func() {
+		if f.start != nil {
+			go f.start()
+		}
+	})
+}
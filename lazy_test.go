@@ -0,0 +1,126 @@
+package async_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/denisss025/go-async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLazy(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	newLazy := func(calls *int32) *Future[int] {
+		return Lazy(ctx, func(_ context.Context) (int, error) {
+			atomic.AddInt32(calls, 1)
+
+			return 5, nil
+		})
+	}
+
+	t.Run("not started", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int32
+
+		f := newLazy(&calls)
+
+		time.Sleep(time.Millisecond)
+
+		_, _, ok := f.TryGet()
+		assert.False(t, ok)
+		assert.Equal(t, Pending, f.State())
+		assert.Zero(t, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("start on await", func(t *testing.T) {
+		t.Parallel()
+
+		const awaiters = 10
+
+		var calls int32
+
+		f := newLazy(&calls)
+
+		wg := new(sync.WaitGroup)
+		wg.Add(awaiters)
+
+		for i := 0; i < awaiters; i++ {
+			go func() {
+				defer wg.Done()
+
+				five, err := f.Await(ctx)
+
+				assert.NoError(t, err)
+				assert.Equal(t, 5, five)
+			}()
+		}
+
+		wg.Wait()
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("start on then", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int32
+
+		ten := newLazy(&calls).Then(ctx, func(_ context.Context, i int) (int, error) {
+			return i * 2, nil
+		})
+
+		v, err := ten.Await(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 10, v)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("explicit start", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int32
+
+		f := newLazy(&calls)
+		f.Start()
+		f.Start()
+
+		<-f.Done()
+
+		five, err, ok := f.TryGet()
+		assert.True(t, ok)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, five)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("cancel before start", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int32
+
+		f := newLazy(&calls)
+		f.Cancel()
+
+		_, err := f.Await(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Zero(t, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("combinators", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int32
+
+		nums, err := All(ctx, newLazy(&calls), newLazy(&calls)).Await(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{5, 5}, nums)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+}
@@ -0,0 +1,55 @@
+package async
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/denisss025/go-async/pipeline"
+)
+
+// PanicError is the error of a Future whose task panicked.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+// Error returns the panic value as a string.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("async: task panicked: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+
+	return err
+}
+
+type crashOnPanicKey struct{}
+
+// CrashOnPanic returns a copy of the parent context that disables panic
+// recovery for the tasks that run with it, so a panic inside such a task
+// crashes the process.
+func CrashOnPanic(parent context.Context) context.Context {
+	return context.WithValue(parent, crashOnPanicKey{}, true)
+}
+
+// run calls function fn and converts its panic into a PanicError unless
+// the recovery is disabled by the context.
+func run[T any](ctx context.Context, fn func() (T, error)) (
+	result pipeline.Result[T]) {
+	if ctx.Value(crashOnPanicKey{}) == nil {
+		defer func() {
+			if r := recover(); r != nil {
+				result = pipeline.Result[T]{
+					Err: &PanicError{Value: r, Stack: debug.Stack()},
+				}
+			}
+		}()
+	}
+
+	result.Val, result.Err = fn()
+
+	return result
+}
@@ -0,0 +1,75 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"testing"
+
+	. "github.com/denisss025/go-async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPanic(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("exec", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Exec(ctx, func(_ context.Context) (int, error) {
+			panic("boom")
+		}).Await(ctx)
+
+		var panicErr *PanicError
+
+		assert.True(t, errors.As(err, &panicErr))
+		assert.Equal(t, "boom", panicErr.Value)
+		assert.Contains(t, string(panicErr.Stack), "panic_test.go")
+	})
+
+	t.Run("then", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Exec(ctx, func(_ context.Context) (int, error) {
+			return 5, nil
+		}).Then(ctx, func(_ context.Context, _ int) (int, error) {
+			panic(io.EOF)
+		}).Await(ctx)
+
+		var panicErr *PanicError
+
+		assert.True(t, errors.As(err, &panicErr))
+		assert.ErrorIs(t, err, io.EOF)
+	})
+
+}
+
+func TestCrashOnPanic(t *testing.T) {
+	t.Parallel()
+
+	const env = "ASYNC_TEST_CRASH_ON_PANIC"
+
+	if os.Getenv(env) != "" {
+		ctx := CrashOnPanic(context.Background())
+
+		_, _ = Exec(ctx, func(_ context.Context) (int, error) {
+			panic("boom")
+		}).Await(ctx)
+
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestCrashOnPanic$")
+	cmd.Env = append(os.Environ(), env+"=1")
+
+	out, err := cmd.CombinedOutput()
+
+	var exitErr *exec.ExitError
+
+	assert.True(t, errors.As(err, &exitErr))
+	assert.Contains(t, string(out), "panic: boom")
+}
@@ -0,0 +1,79 @@
+package pipeline
+
+import (
+	"context"
+	"time"
+)
+
+// Batch collects values of an input channel to slices. A slice is sent to
+// the output channel when it reaches maxSize values or when maxWait elapses
+// since its first value. The rest of the values is sent when the input
+// channel is closed. Zero maxSize or maxWait means no limit.
+func Batch[T any](ctx context.Context, input <-chan T, maxSize int,
+	maxWait time.Duration) <-chan []T {
+	c := make(chan []T)
+
+	go func(ctx context.Context, out chan<- []T, in <-chan T) {
+		defer close(out)
+
+		var (
+			batch   []T
+			timer   *time.Timer
+			timeout <-chan time.Time
+		)
+
+		defer func() {
+			if timer != nil {
+				timer.Stop()
+			}
+		}()
+
+		flush := func() bool {
+			if timer != nil {
+				timer.Stop()
+				timer, timeout = nil, nil
+			}
+
+			if len(batch) == 0 {
+				return true
+			}
+
+			select {
+			case <-ctx.Done():
+				return false
+			case out <- batch:
+				batch = nil
+
+				return true
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-in:
+				if !ok {
+					flush()
+
+					return
+				}
+
+				if batch = append(batch, v); len(batch) == 1 && maxWait > 0 {
+					timer = time.NewTimer(maxWait)
+					timeout = timer.C
+				}
+
+				if maxSize > 0 && len(batch) >= maxSize && !flush() {
+					return
+				}
+			case <-timeout:
+				if !flush() {
+					return
+				}
+			}
+		}
+	}(ctx, c, input)
+
+	return c
+}
@@ -0,0 +1,72 @@
+package pipeline_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/denisss025/go-async/pipeline"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatch(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("by size", func(t *testing.T) {
+		t.Parallel()
+
+		batches := testCollect(Batch(ctx, Range(ctx, 0, 10), 4, time.Minute))
+
+		assert.Equal(t, [][]int{{0, 1, 2, 3}, {4, 5, 6, 7}, {8, 9}}, batches)
+	})
+
+	t.Run("by time", func(t *testing.T) {
+		t.Parallel()
+
+		in := make(chan int)
+		out := Batch(ctx, in, 100, time.Millisecond)
+
+		in <- 1
+		in <- 2
+
+		assert.Equal(t, []int{1, 2}, <-out)
+
+		in <- 3
+		close(in)
+
+		assert.Equal(t, [][]int{{3}}, testCollect(out))
+	})
+
+	t.Run("without limits", func(t *testing.T) {
+		t.Parallel()
+
+		batches := testCollect(Batch(ctx, Range(ctx, 0, 10), 0, 0))
+
+		assert.Equal(t, [][]int{{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}}, batches)
+	})
+
+	t.Run("unroll", func(t *testing.T) {
+		t.Parallel()
+
+		nums := testCollect(Unroll(ctx, Batch(ctx, Range(ctx, 0, 10), 3, 0)))
+
+		assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, nums)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(ctx)
+
+		in := make(chan int)
+		out := Batch(ctx, in, 10, 0)
+
+		in <- 1
+
+		cancel()
+
+		assert.Empty(t, testCollect(out))
+	})
+}
@@ -0,0 +1,109 @@
+package pipeline
+
+import "context"
+
+// SlowConsumerPolicy defines how a value is delivered to a subscriber whose
+// buffer is full.
+type SlowConsumerPolicy int
+
+// Slow consumer policies.
+const (
+	// Block waits until the subscriber has room for the value, so that
+	// a slow subscriber stalls all the others.
+	Block SlowConsumerPolicy = iota
+	// DropOldest drops the oldest buffered value of the subscriber. Works
+	// like DropNewest for unbuffered subscribers.
+	DropOldest
+	// DropNewest drops the value for the subscriber.
+	DropNewest
+	// Disconnect closes the channel of the subscriber, so that it receives
+	// no more values.
+	Disconnect
+)
+
+// BroadcastOptions configures Broadcast.
+type BroadcastOptions struct {
+	// BufferSize is the size of the buffer of every subscriber.
+	BufferSize int
+	// Policy defines how a value is delivered to a subscriber whose buffer
+	// is full.
+	Policy SlowConsumerPolicy
+}
+
+// deliver sends the value to the channel according to the policy. It returns
+// false when the channel must be disconnected.
+func deliver[T any](ctx context.Context, policy SlowConsumerPolicy,
+	out chan T, v T) bool {
+	select {
+	case out <- v:
+		return true
+	default:
+	}
+
+	switch policy {
+	case DropOldest:
+		if cap(out) == 0 {
+			return true
+		}
+
+		for {
+			select {
+			case <-out:
+			default:
+			}
+
+			select {
+			case out <- v:
+				return true
+			default:
+			}
+		}
+	case DropNewest:
+		return true
+	case Disconnect:
+		return false
+	default:
+		select {
+		case <-ctx.Done():
+		case out <- v:
+		}
+
+		return true
+	}
+}
+
+// Broadcast returns n channels that repeat an input channel. Unlike Split,
+// it uses a single goroutine and a buffer per output channel, and handles
+// slow consumers according to the policy.
+func Broadcast[T any](ctx context.Context, input <-chan T, n int,
+	opts BroadcastOptions) (out []<-chan T) {
+	chans := make([]chan T, n)
+	out = make([]<-chan T, n)
+
+	for i := range chans {
+		chans[i] = make(chan T, opts.BufferSize)
+		out[i] = chans[i]
+	}
+
+	go func(ctx context.Context, subs []chan T, in <-chan T) {
+		defer func() {
+			for _, c := range subs {
+				if c != nil {
+					close(c)
+				}
+			}
+		}()
+
+		for v := range in {
+			for i, c := range subs {
+				if c != nil && !deliver(ctx, opts.Policy, c, v) {
+					close(c)
+
+					subs[i] = nil
+				}
+			}
+		}
+	}(ctx, chans, OrDone(ctx, input))
+
+	return out
+}
@@ -0,0 +1,138 @@
+package pipeline_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/denisss025/go-async/pipeline"
+	"github.com/stretchr/testify/assert"
+)
+
+func testBroadcastCollect(chans []<-chan int) [][]int {
+	r := make([][]int, len(chans))
+
+	wg := new(sync.WaitGroup)
+	wg.Add(len(chans))
+
+	for i := range chans {
+		go func(i int) {
+			defer wg.Done()
+
+			r[i] = testCollect(chans[i])
+		}(i)
+	}
+
+	wg.Wait()
+
+	return r
+}
+
+// testBroadcastLockstep reads the first output channel in lockstep with
+// the input, and the second one only after the input is over.
+func testBroadcastLockstep(n int, opts BroadcastOptions) (fast, slow []int) {
+	ctx := context.Background()
+	in := make(chan int)
+	out := Broadcast(ctx, in, 2, opts)
+
+	for i := 0; i < n; i++ {
+		in <- i
+
+		fast = append(fast, <-out[0])
+	}
+
+	close(in)
+
+	// The first channel is closed after all the values are dispatched.
+	for v := range out[0] {
+		fast = append(fast, v)
+	}
+
+	return fast, testCollect(out[1])
+}
+
+func TestBroadcast(t *testing.T) {
+	t.Parallel()
+
+	const n = 100
+
+	ctx := context.Background()
+
+	expect := make([]int, n)
+
+	for i := range expect {
+		expect[i] = i
+	}
+
+	t.Run("block", func(t *testing.T) {
+		t.Parallel()
+
+		out := Broadcast(ctx, Range(ctx, 0, n), 5, BroadcastOptions{})
+		assert.Len(t, out, 5)
+
+		for _, r := range testBroadcastCollect(out) {
+			assert.Equal(t, expect, r)
+		}
+	})
+
+	t.Run("drop newest", func(t *testing.T) {
+		t.Parallel()
+
+		const size = 10
+
+		fast, slow := testBroadcastLockstep(n, BroadcastOptions{
+			BufferSize: size,
+			Policy:     DropNewest,
+		})
+
+		assert.Equal(t, expect, fast)
+		assert.Equal(t, expect[:size], slow)
+	})
+
+	t.Run("drop oldest", func(t *testing.T) {
+		t.Parallel()
+
+		const size = 10
+
+		fast, slow := testBroadcastLockstep(n, BroadcastOptions{
+			BufferSize: size,
+			Policy:     DropOldest,
+		})
+
+		assert.Equal(t, expect, fast)
+		assert.Equal(t, expect[n-size:], slow)
+	})
+
+	t.Run("disconnect", func(t *testing.T) {
+		t.Parallel()
+
+		const size = 10
+
+		fast, slow := testBroadcastLockstep(n, BroadcastOptions{
+			BufferSize: size,
+			Policy:     Disconnect,
+		})
+
+		assert.Equal(t, expect, fast)
+		assert.Equal(t, expect[:size], slow)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(ctx)
+
+		defer cancel()
+
+		out := Broadcast(ctx, Generate(ctx, func(_ context.Context) (int, bool) {
+			return 1, true
+		}), 3, BroadcastOptions{})
+
+		time.AfterFunc(time.Millisecond, cancel)
+
+		for _, r := range testBroadcastCollect(out) {
+			assert.NotEmpty(t, r)
+		}
+	})
+}
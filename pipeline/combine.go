@@ -0,0 +1,208 @@
+package pipeline
+
+import "context"
+
+// Pair holds two values of different types.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Tuple3 holds three values of different types.
+type Tuple3[A, B, C any] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+func receive[T any](ctx context.Context, in <-chan T) (v T, ok bool) {
+	select {
+	case <-ctx.Done():
+		return v, false
+	case v, ok = <-in:
+		return v, ok
+	}
+}
+
+// Zip2 pairs the values of two input channels by their positions. The output
+// channel is closed when any of the input channels is closed.
+func Zip2[A, B any](ctx context.Context, a <-chan A,
+	b <-chan B) <-chan Pair[A, B] {
+	c := make(chan Pair[A, B])
+
+	go func(ctx context.Context, out chan<- Pair[A, B]) {
+		defer close(out)
+
+		for {
+			var (
+				p  Pair[A, B]
+				ok bool
+			)
+
+			if p.First, ok = receive(ctx, a); !ok {
+				return
+			}
+
+			if p.Second, ok = receive(ctx, b); !ok {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case out <- p:
+			}
+		}
+	}(ctx, c)
+
+	return c
+}
+
+// Zip3 groups the values of three input channels by their positions.
+// The output channel is closed when any of the input channels is closed.
+func Zip3[A, B, C any](ctx context.Context, a <-chan A, b <-chan B,
+	c <-chan C) <-chan Tuple3[A, B, C] {
+	ch := make(chan Tuple3[A, B, C])
+
+	go func(ctx context.Context, out chan<- Tuple3[A, B, C]) {
+		defer close(out)
+
+		for {
+			var (
+				t  Tuple3[A, B, C]
+				ok bool
+			)
+
+			if t.First, ok = receive(ctx, a); !ok {
+				return
+			}
+
+			if t.Second, ok = receive(ctx, b); !ok {
+				return
+			}
+
+			if t.Third, ok = receive(ctx, c); !ok {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case out <- t:
+			}
+		}
+	}(ctx, ch)
+
+	return ch
+}
+
+// CombineLatest sends the latest values of both input channels every time
+// any of them receives a value, once both of them have received at least one
+// value. The output channel is closed when both input channels are closed
+// or when an input channel is closed without any value.
+func CombineLatest[A, B any](ctx context.Context, a <-chan A,
+	b <-chan B) <-chan Pair[A, B] {
+	c := make(chan Pair[A, B])
+
+	go func(ctx context.Context, out chan<- Pair[A, B], a <-chan A,
+		b <-chan B) {
+		defer close(out)
+
+		var (
+			latest     Pair[A, B]
+			hasA, hasB bool
+		)
+
+		for a != nil || b != nil {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-a:
+				if !ok {
+					if !hasA {
+						return
+					}
+
+					a = nil
+
+					continue
+				}
+
+				latest.First, hasA = v, true
+			case v, ok := <-b:
+				if !ok {
+					if !hasB {
+						return
+					}
+
+					b = nil
+
+					continue
+				}
+
+				latest.Second, hasB = v, true
+			}
+
+			if !hasA || !hasB {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case out <- latest:
+			}
+		}
+	}(ctx, c, a, b)
+
+	return c
+}
+
+// WithLatestFrom pairs every value of channel a with the latest value of
+// channel b. The values of channel a received before the first value of
+// channel b are dropped. The output channel is closed when channel a is
+// closed.
+func WithLatestFrom[A, B any](ctx context.Context, a <-chan A,
+	b <-chan B) <-chan Pair[A, B] {
+	c := make(chan Pair[A, B])
+
+	go func(ctx context.Context, out chan<- Pair[A, B], b <-chan B) {
+		defer close(out)
+
+		var (
+			latest B
+			hasB   bool
+		)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-b:
+				if !ok {
+					b = nil
+
+					continue
+				}
+
+				latest, hasB = v, true
+			case v, ok := <-a:
+				if !ok {
+					return
+				}
+
+				if !hasB {
+					continue
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case out <- Pair[A, B]{First: v, Second: latest}:
+				}
+			}
+		}
+	}(ctx, c, b)
+
+	return c
+}
@@ -0,0 +1,116 @@
+package pipeline_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/denisss025/go-async/pipeline"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZip(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("zip2", func(t *testing.T) {
+		t.Parallel()
+
+		pairs := testCollect(Zip2(ctx, Range(ctx, 0, 5),
+			ToChan(ctx, "a", "b", "c")))
+
+		assert.Equal(t, []Pair[int, string]{
+			{First: 0, Second: "a"},
+			{First: 1, Second: "b"},
+			{First: 2, Second: "c"},
+		}, pairs)
+	})
+
+	t.Run("zip3", func(t *testing.T) {
+		t.Parallel()
+
+		tuples := testCollect(Zip3(ctx, ToChan(ctx, 1, 2),
+			ToChan(ctx, "a", "b", "c"), ToChan(ctx, true, false, true)))
+
+		assert.Equal(t, []Tuple3[int, string, bool]{
+			{First: 1, Second: "a", Third: true},
+			{First: 2, Second: "b", Third: false},
+		}, tuples)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(ctx)
+		out := Zip2(ctx, make(chan int), make(chan int))
+
+		cancel()
+
+		assert.Empty(t, testCollect(out))
+	})
+}
+
+func TestCombineLatest(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("latest", func(t *testing.T) {
+		t.Parallel()
+
+		prices, positions := make(chan float64), make(chan int)
+		out := CombineLatest(ctx, prices, positions)
+
+		prices <- 1.5
+		prices <- 2
+		positions <- 10
+
+		assert.Equal(t, Pair[float64, int]{First: 2, Second: 10}, <-out)
+
+		positions <- 20
+
+		assert.Equal(t, Pair[float64, int]{First: 2, Second: 20}, <-out)
+
+		close(positions)
+		prices <- 3
+
+		assert.Equal(t, Pair[float64, int]{First: 3, Second: 20}, <-out)
+
+		close(prices)
+
+		assert.Empty(t, testCollect(out))
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		t.Parallel()
+
+		out := CombineLatest(ctx, make(chan int), ToChan[string](ctx))
+
+		assert.Empty(t, testCollect(out))
+	})
+}
+
+func TestWithLatestFrom(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	a, b := make(chan int), make(chan string)
+	out := WithLatestFrom(ctx, a, b)
+
+	a <- 1
+	b <- "x"
+	a <- 2
+
+	assert.Equal(t, Pair[int, string]{First: 2, Second: "x"}, <-out)
+
+	b <- "y"
+	close(b)
+	a <- 3
+
+	assert.Equal(t, Pair[int, string]{First: 3, Second: "y"}, <-out)
+
+	close(a)
+
+	assert.Empty(t, testCollect(out))
+}
@@ -0,0 +1,191 @@
+package pipeline
+
+import (
+	"context"
+	"sync"
+)
+
+// HubOptions configures a Hub.
+type HubOptions struct {
+	// BufferSize is the size of the buffer of every subscriber.
+	BufferSize int
+	// Policy defines how a value is delivered to a subscriber whose buffer
+	// is full.
+	Policy SlowConsumerPolicy
+	// Replay is the number of the last values that are sent to every new
+	// subscriber.
+	Replay int
+}
+
+type subscriber[T any] struct {
+	ctx     context.Context
+	out     chan T
+	filters []func(T) bool
+
+	mu     sync.Mutex
+	closed bool
+}
+
+func (s *subscriber[T]) match(v T) bool {
+	for _, filter := range s.filters {
+		if !filter(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// send delivers the value unless the channel of the subscriber is closed.
+// It returns false when the subscriber must be disconnected.
+func (s *subscriber[T]) send(policy SlowConsumerPolicy, v T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed || !s.match(v) {
+		return true
+	}
+
+	return deliver(s.ctx, policy, s.out, v)
+}
+
+func (s *subscriber[T]) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.closed {
+		s.closed = true
+
+		close(s.out)
+	}
+}
+
+// Hub repeats an input channel to any number of subscribers, which can
+// subscribe and unsubscribe while the input channel is running.
+type Hub[T any] struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	opts   HubOptions
+
+	mu     sync.Mutex
+	subs   map[*subscriber[T]]struct{}
+	replay []T
+	closed bool
+}
+
+// NewHub creates a new Hub for the input channel. The Hub closes the channels
+// of all its subscribers when the input channel is closed or the context is
+// done.
+func NewHub[T any](ctx context.Context, input <-chan T,
+	opts HubOptions) *Hub[T] {
+	h := &Hub[T]{
+		opts: opts,
+		subs: make(map[*subscriber[T]]struct{}),
+	}
+
+	h.ctx, h.cancel = context.WithCancel(ctx)
+
+	go func(in <-chan T) {
+		defer h.close()
+
+		for v := range in {
+			h.publish(v)
+		}
+	}(OrDone(h.ctx, input))
+
+	return h
+}
+
+// Subscribe returns a channel that receives the values of the Hub that match
+// all the filters, starting with the replayed ones. The subscriber is
+// unsubscribed and the channel is closed when the context is done.
+func (h *Hub[T]) Subscribe(ctx context.Context,
+	filters ...func(T) bool) <-chan T {
+	sub := &subscriber[T]{
+		out:     make(chan T, h.opts.BufferSize+h.opts.Replay),
+		filters: filters,
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	sub.ctx = ctx
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, v := range h.replay {
+		if sub.match(v) {
+			sub.out <- v
+		}
+	}
+
+	if h.closed {
+		cancel()
+		close(sub.out)
+
+		return sub.out
+	}
+
+	h.subs[sub] = struct{}{}
+
+	go func() {
+		defer cancel()
+
+		select {
+		case <-h.ctx.Done():
+			// Unblocks the delivery, the Hub closes the channel itself.
+		case <-ctx.Done():
+			h.remove(sub)
+		}
+	}()
+
+	return sub.out
+}
+
+// publish delivers the value to a snapshot of the subscribers, so that
+// a blocked subscriber can still subscribe and unsubscribe.
+func (h *Hub[T]) publish(v T) {
+	h.mu.Lock()
+
+	if h.opts.Replay > 0 {
+		if len(h.replay) == h.opts.Replay {
+			h.replay = append(h.replay[:0], h.replay[1:]...)
+		}
+
+		h.replay = append(h.replay, v)
+	}
+
+	subs := make([]*subscriber[T], 0, len(h.subs))
+
+	for sub := range h.subs {
+		subs = append(subs, sub)
+	}
+
+	h.mu.Unlock()
+
+	for _, sub := range subs {
+		if !sub.send(h.opts.Policy, v) {
+			h.remove(sub)
+		}
+	}
+}
+
+func (h *Hub[T]) remove(sub *subscriber[T]) {
+	h.mu.Lock()
+	delete(h.subs, sub)
+	h.mu.Unlock()
+
+	sub.close()
+}
+
+func (h *Hub[T]) close() {
+	h.cancel()
+
+	h.mu.Lock()
+	subs := h.subs
+	h.subs, h.closed = nil, true
+	h.mu.Unlock()
+
+	for sub := range subs {
+		sub.close()
+	}
+}
@@ -0,0 +1,140 @@
+package pipeline_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/denisss025/go-async/pipeline"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHub(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("subscribe", func(t *testing.T) {
+		t.Parallel()
+
+		in := make(chan int)
+		hub := NewHub(ctx, in, HubOptions{})
+
+		all := hub.Subscribe(ctx)
+		even := hub.Subscribe(ctx, func(i int) bool { return i%2 == 0 })
+
+		go func() {
+			defer close(in)
+
+			for i := 0; i < 10; i++ {
+				in <- i
+			}
+		}()
+
+		r := testBroadcastCollect([]<-chan int{all, even})
+
+		assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, r[0])
+		assert.Equal(t, []int{0, 2, 4, 6, 8}, r[1])
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		t.Parallel()
+
+		in := make(chan int)
+		hub := NewHub(ctx, in, HubOptions{Replay: 3})
+		first := hub.Subscribe(ctx)
+
+		for i := 0; i < 5; i++ {
+			in <- i
+
+			assert.Equal(t, i, <-first)
+		}
+
+		late := hub.Subscribe(ctx)
+		odd := hub.Subscribe(ctx, func(i int) bool { return i%2 != 0 })
+
+		in <- 5
+		close(in)
+
+		r := testBroadcastCollect([]<-chan int{first, late, odd})
+
+		assert.Equal(t, []int{5}, r[0])
+		assert.Equal(t, []int{2, 3, 4, 5}, r[1])
+		assert.Equal(t, []int{3, 5}, r[2])
+		assert.Equal(t, []int{3, 4, 5}, testCollect(hub.Subscribe(ctx)))
+	})
+
+	t.Run("unsubscribe", func(t *testing.T) {
+		t.Parallel()
+
+		in := make(chan int)
+		hub := NewHub(ctx, in, HubOptions{BufferSize: 1})
+
+		subCtx, unsubscribe := context.WithCancel(ctx)
+
+		first := hub.Subscribe(ctx)
+		second := hub.Subscribe(subCtx)
+
+		in <- 1
+
+		assert.Equal(t, 1, <-first)
+		assert.Equal(t, 1, <-second)
+
+		unsubscribe()
+
+		assert.Empty(t, testCollect(second))
+
+		in <- 2
+		close(in)
+
+		assert.Equal(t, []int{2}, testCollect(first))
+	})
+
+	t.Run("subscribe while reading", func(t *testing.T) {
+		t.Parallel()
+
+		in := make(chan int)
+		hub := NewHub(ctx, in, HubOptions{})
+		first := hub.Subscribe(ctx)
+
+		go func() {
+			defer close(in)
+
+			for i := 0; i < 3; i++ {
+				in <- i
+			}
+		}()
+
+		var (
+			values []int
+			late   <-chan int
+		)
+
+		for v := range first {
+			values = append(values, v)
+
+			if late == nil {
+				// Lets the Hub block on the delivery of the next value.
+				time.Sleep(time.Millisecond)
+
+				late = hub.Subscribe(ctx)
+				go testCollect(late)
+			}
+		}
+
+		assert.Equal(t, []int{0, 1, 2}, values)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(ctx)
+
+		hub := NewHub(ctx, make(chan int), HubOptions{})
+		sub := hub.Subscribe(context.Background())
+
+		cancel()
+
+		assert.Empty(t, testCollect(sub))
+	})
+}
@@ -0,0 +1,229 @@
+package pipeline
+
+import (
+	"context"
+	"time"
+)
+
+// JoinMode defines which values Join sends to the output channel.
+type JoinMode int
+
+// Join modes.
+const (
+	// InnerJoin sends only the matched pairs of values.
+	InnerJoin JoinMode = iota
+	// LeftOuterJoin also sends the values of the left channel that have not
+	// been matched within the window.
+	LeftOuterJoin
+)
+
+// JoinOptions configures Join.
+type JoinOptions struct {
+	// Mode defines which values are sent to the output channel. InnerJoin
+	// when zero.
+	Mode JoinMode
+}
+
+// Joined holds a value of the left channel and the matched value of
+// the right channel. Matched is false for an unmatched value of the left
+// channel of a left outer join.
+type Joined[L, R any] struct {
+	Left    L
+	Right   R
+	Matched bool
+}
+
+type joinEntry[T any, K comparable] struct {
+	key     K
+	val     T
+	at      time.Time
+	matched bool
+}
+
+// joinState keeps the values of one side of a join in the order of arrival.
+type joinState[T any, K comparable] struct {
+	queue []*joinEntry[T, K]
+	byKey map[K][]*joinEntry[T, K]
+}
+
+func (s *joinState[T, K]) add(e *joinEntry[T, K]) {
+	s.queue = append(s.queue, e)
+	s.byKey[e.key] = append(s.byKey[e.key], e)
+}
+
+// expire removes and returns the values received before the deadline.
+func (s *joinState[T, K]) expire(deadline time.Time) (
+	expired []*joinEntry[T, K]) {
+	for len(s.queue) > 0 && !s.queue[0].at.After(deadline) {
+		e := s.queue[0]
+		s.queue = s.queue[1:]
+
+		if entries := s.byKey[e.key][1:]; len(entries) > 0 {
+			s.byKey[e.key] = entries
+		} else {
+			delete(s.byKey, e.key)
+		}
+
+		expired = append(expired, e)
+	}
+
+	return expired
+}
+
+// Join matches the values of two input channels with equal keys that arrive
+// within the window of each other. Every matched pair is sent to the output
+// channel. The values are expired after the window. In LeftOuterJoin mode
+// the expired values of the left channel that have not been matched are sent
+// with Matched set to false, and so are the rest of them when both input
+// channels are closed. Panics when window is not positive.
+func Join[L, R any, K comparable](ctx context.Context, left <-chan L,
+	right <-chan R, leftKey func(L) K, rightKey func(R) K,
+	window time.Duration, opts JoinOptions) <-chan Joined[L, R] {
+	if window <= 0 {
+		panic("join window must be greater than 0")
+	}
+
+	c := make(chan Joined[L, R])
+
+	go func(ctx context.Context, out chan<- Joined[L, R], left <-chan L,
+		right <-chan R) {
+		defer close(out)
+
+		var (
+			lefts   = joinState[L, K]{byKey: make(map[K][]*joinEntry[L, K])}
+			rights  = joinState[R, K]{byKey: make(map[K][]*joinEntry[R, K])}
+			timer   = time.NewTimer(window)
+			armed   = true
+			timeout <-chan time.Time
+		)
+
+		defer timer.Stop()
+
+		send := func(j Joined[L, R]) bool {
+			select {
+			case <-ctx.Done():
+				return false
+			case out <- j:
+				return true
+			}
+		}
+
+		// unmatched sends the unmatched values of the left channel in
+		// LeftOuterJoin mode.
+		unmatched := func(entries []*joinEntry[L, K]) bool {
+			for _, e := range entries {
+				if opts.Mode == LeftOuterJoin && !e.matched &&
+					!send(Joined[L, R]{Left: e.val}) {
+					return false
+				}
+			}
+
+			return true
+		}
+
+		// expire removes the values that are older than the window, so
+		// that they are not matched even when the timer is late.
+		expire := func(now time.Time) bool {
+			deadline := now.Add(-window)
+
+			rights.expire(deadline)
+
+			return unmatched(lefts.expire(deadline))
+		}
+
+		// schedule wakes the operator up when the oldest value expires.
+		schedule := func() {
+			if armed && !timer.Stop() {
+				<-timer.C
+			}
+
+			armed, timeout = false, nil
+
+			var oldest time.Time
+
+			if len(lefts.queue) > 0 {
+				oldest = lefts.queue[0].at
+			}
+
+			if len(rights.queue) > 0 && (oldest.IsZero() ||
+				rights.queue[0].at.Before(oldest)) {
+				oldest = rights.queue[0].at
+			}
+
+			if !oldest.IsZero() {
+				timer.Reset(time.Until(oldest.Add(window)))
+				armed, timeout = true, timer.C
+			}
+		}
+
+		// Stops the timer until the first value arrives.
+		schedule()
+
+		for left != nil || right != nil {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-left:
+				if !ok {
+					left = nil
+
+					break
+				}
+
+				now := time.Now()
+				if !expire(now) {
+					return
+				}
+
+				e := &joinEntry[L, K]{key: leftKey(v), val: v, at: now}
+
+				for _, r := range rights.byKey[e.key] {
+					if e.matched = true; !send(Joined[L, R]{
+						Left: v, Right: r.val, Matched: true,
+					}) {
+						return
+					}
+				}
+
+				lefts.add(e)
+			case v, ok := <-right:
+				if !ok {
+					right = nil
+
+					break
+				}
+
+				now := time.Now()
+				if !expire(now) {
+					return
+				}
+
+				e := &joinEntry[R, K]{key: rightKey(v), val: v, at: now}
+
+				for _, l := range lefts.byKey[e.key] {
+					if l.matched = true; !send(Joined[L, R]{
+						Left: l.val, Right: v, Matched: true,
+					}) {
+						return
+					}
+				}
+
+				rights.add(e)
+			case now := <-timeout:
+				if armed = false; !expire(now) {
+					return
+				}
+			}
+
+			if left == nil && len(lefts.queue) == 0 {
+				return
+			}
+
+			schedule()
+		}
+
+		unmatched(lefts.queue)
+	}(ctx, c, left, right)
+
+	return c
+}
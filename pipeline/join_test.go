@@ -0,0 +1,137 @@
+package pipeline_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/denisss025/go-async/pipeline"
+	"github.com/stretchr/testify/assert"
+)
+
+type testRequest struct {
+	ID   int
+	Path string
+}
+
+type testResponse struct {
+	ID     int
+	Status int
+}
+
+func TestJoin(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	reqKey := func(r testRequest) int { return r.ID }
+	respKey := func(r testResponse) int { return r.ID }
+
+	t.Run("inner", func(t *testing.T) {
+		t.Parallel()
+
+		reqs, resps := make(chan testRequest), make(chan testResponse)
+		out := Join(ctx, reqs, resps, reqKey, respKey, time.Minute,
+			JoinOptions{})
+
+		reqs <- testRequest{ID: 1, Path: "/a"}
+		reqs <- testRequest{ID: 2, Path: "/b"}
+		resps <- testResponse{ID: 2, Status: 404}
+
+		assert.Equal(t, Joined[testRequest, testResponse]{
+			Left:    testRequest{ID: 2, Path: "/b"},
+			Right:   testResponse{ID: 2, Status: 404},
+			Matched: true,
+		}, <-out)
+
+		resps <- testResponse{ID: 3, Status: 200}
+		reqs <- testRequest{ID: 3, Path: "/c"}
+
+		assert.Equal(t, Joined[testRequest, testResponse]{
+			Left:    testRequest{ID: 3, Path: "/c"},
+			Right:   testResponse{ID: 3, Status: 200},
+			Matched: true,
+		}, <-out)
+
+		close(reqs)
+		close(resps)
+
+		assert.Empty(t, testCollect(out))
+	})
+
+	t.Run("left outer", func(t *testing.T) {
+		t.Parallel()
+
+		reqs, resps := make(chan testRequest), make(chan testResponse)
+		out := Join(ctx, reqs, resps, reqKey, respKey, time.Minute,
+			JoinOptions{Mode: LeftOuterJoin})
+
+		reqs <- testRequest{ID: 1, Path: "/a"}
+		reqs <- testRequest{ID: 2, Path: "/b"}
+		resps <- testResponse{ID: 2, Status: 200}
+
+		assert.True(t, (<-out).Matched)
+
+		close(reqs)
+		close(resps)
+
+		assert.Equal(t, []Joined[testRequest, testResponse]{
+			{Left: testRequest{ID: 1, Path: "/a"}},
+		}, testCollect(out))
+	})
+
+	t.Run("expire", func(t *testing.T) {
+		t.Parallel()
+
+		reqs, resps := make(chan testRequest), make(chan testResponse)
+		out := Join(ctx, reqs, resps, reqKey, respKey, time.Millisecond,
+			JoinOptions{Mode: LeftOuterJoin})
+
+		reqs <- testRequest{ID: 1, Path: "/a"}
+
+		assert.Equal(t, Joined[testRequest, testResponse]{
+			Left: testRequest{ID: 1, Path: "/a"},
+		}, <-out)
+
+		resps <- testResponse{ID: 1, Status: 200}
+		close(reqs)
+
+		assert.Empty(t, testCollect(out))
+	})
+
+	t.Run("stale values", func(t *testing.T) {
+		t.Parallel()
+
+		reqs, resps := make(chan testRequest), make(chan testResponse)
+		out := Join(ctx, reqs, resps, reqKey, respKey, 5*time.Millisecond,
+			JoinOptions{})
+
+		reqs <- testRequest{ID: 1, Path: "/a"}
+		reqs <- testRequest{ID: 2, Path: "/b"}
+		resps <- testResponse{ID: 2, Status: 200}
+
+		// The Join is blocked on the slow consumer while the window expires.
+		time.Sleep(20 * time.Millisecond)
+
+		assert.True(t, (<-out).Matched)
+
+		resps <- testResponse{ID: 1, Status: 200}
+
+		close(reqs)
+		close(resps)
+
+		assert.Empty(t, testCollect(out))
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(ctx)
+		out := Join(ctx, make(chan testRequest), make(chan testResponse),
+			reqKey, respKey, time.Minute, JoinOptions{})
+
+		cancel()
+
+		assert.Empty(t, testCollect(out))
+	})
+}
@@ -0,0 +1,223 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/binary"
+	"hash/maphash"
+	"math"
+	"reflect"
+	"time"
+)
+
+// Partition returns n channels and sends every value of an input channel to
+// one of them. The channel is chosen by the hash of the value key, so values
+// with equal keys are sent to the same channel in the order of the input
+// channel. Panics when n is less than 1.
+func Partition[T any, K comparable](ctx context.Context, input <-chan T, n int,
+	keyFn func(T) K) []<-chan T {
+	if n < 1 {
+		panic("number of partitions must be greater than 0")
+	}
+
+	chans := make([]chan T, n)
+	out := make([]<-chan T, n)
+
+	for i := range chans {
+		chans[i] = make(chan T)
+		out[i] = chans[i]
+	}
+
+	go func(ctx context.Context, out []chan T, in <-chan T) {
+		defer func() {
+			for _, c := range out {
+				close(c)
+			}
+		}()
+
+		for v := range OrDone(ctx, in) {
+			select {
+			case <-ctx.Done():
+				return
+			case out[hashKey(keyFn(v))%uint64(len(out))] <- v:
+			}
+		}
+	}(ctx, chans, input)
+
+	return out
+}
+
+var keySeed = maphash.MakeSeed()
+
+// hashKey returns the hash of the key, so that equal keys have equal hashes.
+func hashKey[K comparable](key K) uint64 {
+	var h maphash.Hash
+
+	h.SetSeed(keySeed)
+
+	switch k := any(key).(type) {
+	case string:
+		_, _ = h.WriteString(k)
+	case int:
+		writeUint(&h, uint64(k))
+	case int64:
+		writeUint(&h, uint64(k))
+	case uint64:
+		writeUint(&h, k)
+	default:
+		writeValue(&h, reflect.ValueOf(key))
+	}
+
+	return h.Sum64()
+}
+
+func writeUint(h *maphash.Hash, u uint64) {
+	var b [8]byte
+
+	binary.LittleEndian.PutUint64(b[:], u)
+	_, _ = h.Write(b[:])
+}
+
+func writeFloat(h *maphash.Hash, f float64) {
+	if f == 0 {
+		f = 0 // -0 == 0, so both of them must have the same hash.
+	}
+
+	writeUint(h, math.Float64bits(f))
+}
+
+// writeValue writes the value of a comparable type to the hash.
+func writeValue(h *maphash.Hash, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.String:
+		_, _ = h.WriteString(v.String())
+	case reflect.Bool:
+		if v.Bool() {
+			_ = h.WriteByte(1)
+		} else {
+			_ = h.WriteByte(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64:
+		writeUint(h, uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		writeUint(h, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeFloat(h, v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		writeFloat(h, real(v.Complex()))
+		writeFloat(h, imag(v.Complex()))
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		writeUint(h, uint64(v.Pointer()))
+	case reflect.Interface:
+		if !v.IsNil() {
+			writeValue(h, v.Elem())
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			writeValue(h, v.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).Name != "_" {
+				writeValue(h, v.Field(i))
+			}
+		}
+	}
+}
+
+// Group is a channel of values with the same key.
+type Group[K comparable, T any] struct {
+	Key    K
+	Values <-chan T
+}
+
+// GroupByOptions configures GroupBy.
+type GroupByOptions struct {
+	// Idle is the time after which a group that gets no values is closed.
+	// The next value with its key starts a new group. Groups are not closed
+	// before the input channel when zero.
+	Idle time.Duration
+}
+
+// GroupBy returns a channel of groups. A new group is sent to the output
+// channel when a value with a new key arrives, and all values with that key
+// are sent to the group channel in the order of the input channel. Every
+// group channel must be read concurrently with the others, because a blocked
+// group blocks the whole operator. All groups are closed when the input
+// channel is closed.
+func GroupBy[T any, K comparable](ctx context.Context, input <-chan T,
+	keyFn func(T) K, opts GroupByOptions) <-chan Group[K, T] {
+	type group struct {
+		values chan T
+		seen   time.Time
+	}
+
+	idle := opts.Idle
+
+	c := make(chan Group[K, T])
+
+	go func(ctx context.Context, out chan<- Group[K, T], in <-chan T) {
+		groups := make(map[K]*group)
+
+		defer func() {
+			for _, g := range groups {
+				close(g.values)
+			}
+
+			close(out)
+		}()
+
+		var evict <-chan time.Time
+
+		if idle > 0 {
+			ticker := time.NewTicker(idle)
+			defer ticker.Stop()
+
+			evict = ticker.C
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-evict:
+				for key, g := range groups {
+					if now.Sub(g.seen) >= idle {
+						close(g.values)
+						delete(groups, key)
+					}
+				}
+			case v, ok := <-in:
+				if !ok {
+					return
+				}
+
+				key := keyFn(v)
+
+				g, ok := groups[key]
+				if !ok {
+					g = &group{values: make(chan T)}
+
+					select {
+					case <-ctx.Done():
+						close(g.values)
+
+						return
+					case out <- Group[K, T]{Key: key, Values: g.values}:
+						groups[key] = g
+					}
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case g.values <- v:
+					g.seen = time.Now()
+				}
+			}
+		}
+	}(ctx, c, input)
+
+	return c
+}
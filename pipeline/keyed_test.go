@@ -0,0 +1,190 @@
+package pipeline_test
+
+import (
+	"context"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/denisss025/go-async/pipeline"
+	"github.com/stretchr/testify/assert"
+)
+
+func testGroupCollect(groups <-chan Group[int, int]) map[int][][]int {
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+
+	r := make(map[int][][]int)
+
+	for g := range groups {
+		wg.Add(1)
+
+		go func(g Group[int, int]) {
+			defer wg.Done()
+
+			values := testCollect(g.Values)
+
+			mu.Lock()
+			r[g.Key] = append(r[g.Key], values)
+			mu.Unlock()
+		}(g)
+	}
+
+	wg.Wait()
+
+	return r
+}
+
+func TestPartition(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mod3 := func(i int) int { return i % 3 }
+
+	t.Run("by key", func(t *testing.T) {
+		t.Parallel()
+
+		r := testBroadcastCollect(Partition(ctx, Range(ctx, 0, 30), 4, mod3))
+
+		assert.Len(t, r, 4)
+
+		found := make(map[int]bool)
+
+		for _, values := range r {
+			keys := make(map[int]bool)
+
+			for i, v := range values {
+				keys[mod3(v)] = true
+
+				if i > 0 {
+					assert.Less(t, values[i-1], v)
+				}
+			}
+
+			for key := range keys {
+				assert.False(t, found[key], "key %d is in several partitions", key)
+
+				found[key] = true
+			}
+		}
+
+		assert.Len(t, found, 3)
+	})
+
+	t.Run("consistent", func(t *testing.T) {
+		t.Parallel()
+
+		r1 := testBroadcastCollect(Partition(ctx, Range(ctx, 0, 30), 4, mod3))
+		r2 := testBroadcastCollect(Partition(ctx, Range(ctx, 0, 30), 4, mod3))
+
+		assert.Equal(t, r1, r2)
+	})
+
+	t.Run("equal keys", func(t *testing.T) {
+		t.Parallel()
+
+		type key struct {
+			Name  string
+			Score float64
+		}
+
+		keys := []key{
+			{Name: "a", Score: 0}, {Name: "a", Score: math.Copysign(0, -1)},
+			{Name: "b", Score: 1}, {Name: "b", Score: 1},
+		}
+
+		r := testBroadcastCollect(Partition(ctx, Range(ctx, 0, len(keys)), 8,
+			func(i int) key { return keys[i] }))
+
+		partitions := make(map[key]int)
+
+		for p, values := range r {
+			for _, i := range values {
+				if first, ok := partitions[keys[i]]; ok {
+					assert.Equal(t, first, p)
+				}
+
+				partitions[keys[i]] = p
+			}
+		}
+
+		assert.Len(t, partitions, 2)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(ctx)
+		out := Partition(ctx, make(chan int), 2, mod3)
+
+		cancel()
+
+		assert.Equal(t, [][]int{nil, nil}, testBroadcastCollect(out))
+	})
+}
+
+func TestGroupBy(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("by key", func(t *testing.T) {
+		t.Parallel()
+
+		r := testGroupCollect(GroupBy(ctx, Range(ctx, 0, 10),
+			func(i int) int { return i % 3 }, GroupByOptions{}))
+
+		assert.Equal(t, map[int][][]int{
+			0: {{0, 3, 6, 9}},
+			1: {{1, 4, 7}},
+			2: {{2, 5, 8}},
+		}, r)
+	})
+
+	t.Run("idle", func(t *testing.T) {
+		t.Parallel()
+
+		in := make(chan int)
+		groups := GroupBy(ctx, in, func(i int) int { return i % 2 },
+			GroupByOptions{Idle: time.Millisecond})
+
+		in <- 1
+
+		g := <-groups
+		assert.Equal(t, 1, g.Key)
+		assert.Equal(t, 1, <-g.Values)
+
+		_, ok := <-g.Values
+		assert.False(t, ok)
+
+		go func() {
+			defer close(in)
+
+			in <- 3
+		}()
+
+		assert.Equal(t, map[int][][]int{1: {{3}}}, testGroupCollect(groups))
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(ctx)
+
+		in := make(chan int)
+		groups := GroupBy(ctx, in, func(i int) int { return i },
+			GroupByOptions{})
+
+		go func() { in <- 1 }()
+
+		g := <-groups
+
+		cancel()
+
+		assert.LessOrEqual(t, len(testCollect(g.Values)), 1)
+		assert.Empty(t, testCollect(groups))
+	})
+}
@@ -0,0 +1,136 @@
+package pipeline
+
+import (
+	"context"
+	"sync"
+)
+
+// ParallelMap transforms an input chan to an output chan running function
+// mapFn on the given number of goroutines. The values are sent to
+// the output chan as soon as they are ready, so their order is not defined.
+func ParallelMap[T, V any](ctx context.Context, workers int, mapFn func(T) V,
+	input <-chan T) (output <-chan V) {
+	if workers < 2 {
+		return Map(ctx, mapFn, input)
+	}
+
+	c := make(chan V)
+
+	wg := new(sync.WaitGroup)
+	wg.Add(workers)
+
+	in := OrDone(ctx, input)
+
+	for i := 0; i < workers; i++ {
+		go func(ctx context.Context, wg *sync.WaitGroup, out chan<- V) {
+			defer wg.Done()
+
+			for v := range in {
+				select {
+				case <-ctx.Done():
+					return
+				case out <- mapFn(v):
+				}
+			}
+		}(ctx, wg, c)
+	}
+
+	go func(wg *sync.WaitGroup, out chan<- V) {
+		defer close(out)
+
+		wg.Wait()
+	}(wg, c)
+
+	return c
+}
+
+type indexed[T any] struct {
+	idx int
+	val T
+}
+
+// ParallelMapOrdered works like ParallelMap, but sends the values to
+// the output chan in the order of the input chan. It keeps at most twice
+// as many values as there are workers in its reorder buffer.
+func ParallelMapOrdered[T, V any](ctx context.Context, workers int,
+	mapFn func(T) V, input <-chan T) (output <-chan V) {
+	if workers < 2 {
+		return Map(ctx, mapFn, input)
+	}
+
+	jobs := make(chan indexed[T])
+	results := make(chan indexed[V])
+	tokens := make(chan struct{}, 2*workers)
+	c := make(chan V)
+
+	go func(ctx context.Context, out chan<- indexed[T], in <-chan T) {
+		defer close(out)
+
+		var i int
+
+		for v := range in {
+			select {
+			case <-ctx.Done():
+				return
+			case tokens <- struct{}{}:
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case out <- indexed[T]{idx: i, val: v}:
+				i++
+			}
+		}
+	}(ctx, jobs, OrDone(ctx, input))
+
+	wg := new(sync.WaitGroup)
+	wg.Add(workers)
+
+	for i := 0; i < workers; i++ {
+		go func(ctx context.Context, wg *sync.WaitGroup) {
+			defer wg.Done()
+
+			for job := range jobs {
+				select {
+				case <-ctx.Done():
+					return
+				case results <- indexed[V]{idx: job.idx, val: mapFn(job.val)}:
+				}
+			}
+		}(ctx, wg)
+	}
+
+	go func(wg *sync.WaitGroup) {
+		defer close(results)
+
+		wg.Wait()
+	}(wg)
+
+	go func(ctx context.Context, out chan<- V) {
+		defer close(out)
+
+		pending := make(map[int]V, cap(tokens))
+
+		var next int
+
+		for result := range results {
+			pending[result.idx] = result.val
+
+			for v, ok := pending[next]; ok; v, ok = pending[next] {
+				delete(pending, next)
+
+				select {
+				case <-ctx.Done():
+					return
+				case out <- v:
+					next++
+				}
+
+				<-tokens
+			}
+		}
+	}(ctx, c)
+
+	return c
+}
@@ -0,0 +1,136 @@
+package pipeline_test
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/denisss025/go-async/pipeline"
+	"github.com/stretchr/testify/assert"
+)
+
+func testSlowDouble(running, maxRunning *int32) func(int) int {
+	return func(i int) int {
+		n := atomic.AddInt32(running, 1)
+
+		defer atomic.AddInt32(running, -1)
+
+		for {
+			m := atomic.LoadInt32(maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(maxRunning, m, n) {
+				break
+			}
+		}
+
+		time.Sleep(time.Duration(i%7) * 100 * time.Microsecond)
+
+		return i * 2
+	}
+}
+
+func TestParallelMap(t *testing.T) {
+	t.Parallel()
+
+	const (
+		n       = 100
+		workers = 4
+	)
+
+	ctx := context.Background()
+
+	t.Run("unordered", func(t *testing.T) {
+		t.Parallel()
+
+		var running, maxRunning int32
+
+		out := ParallelMap(ctx, workers, testSlowDouble(&running, &maxRunning),
+			Range(ctx, 0, n))
+
+		var result []int
+
+		for v := range out {
+			result = append(result, v)
+		}
+
+		sort.Ints(result)
+
+		assert.Len(t, result, n)
+
+		for i, v := range result {
+			assert.Equal(t, i*2, v)
+		}
+
+		assert.Greater(t, atomic.LoadInt32(&maxRunning), int32(1))
+		assert.LessOrEqual(t, atomic.LoadInt32(&maxRunning), int32(workers))
+	})
+
+	t.Run("ordered", func(t *testing.T) {
+		t.Parallel()
+
+		var running, maxRunning int32
+
+		out := ParallelMapOrdered(ctx, workers,
+			testSlowDouble(&running, &maxRunning), Range(ctx, 0, n))
+
+		var i int
+
+		for v := range out {
+			assert.Equal(t, i*2, v)
+
+			i++
+		}
+
+		assert.Equal(t, n, i)
+		assert.Greater(t, atomic.LoadInt32(&maxRunning), int32(1))
+		assert.LessOrEqual(t, atomic.LoadInt32(&maxRunning), int32(workers))
+	})
+
+	t.Run("single worker", func(t *testing.T) {
+		t.Parallel()
+
+		double := func(i int) int { return i * 2 }
+
+		for _, out := range []<-chan int{
+			ParallelMap(ctx, 1, double, Range(ctx, 0, n)),
+			ParallelMapOrdered(ctx, 1, double, Range(ctx, 0, n)),
+		} {
+			var i int
+
+			for v := range out {
+				assert.Equal(t, i*2, v)
+
+				i++
+			}
+
+			assert.Equal(t, n, i)
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		t.Parallel()
+
+		double := func(i int) int { return i * 2 }
+
+		for _, parallelMap := range []func(context.Context, int, func(int) int,
+			<-chan int) <-chan int{
+			ParallelMap[int, int], ParallelMapOrdered[int, int],
+		} {
+			ctx, cancel := context.WithCancel(ctx)
+
+			var i int
+
+			for range parallelMap(ctx, workers, double, Range(ctx, 0, n)) {
+				if i++; i == n/4 {
+					cancel()
+				}
+			}
+
+			cancel()
+
+			assert.GreaterOrEqual(t, i, n/4)
+			assert.Less(t, i, n)
+		}
+	})
+}
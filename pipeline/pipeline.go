@@ -135,28 +135,40 @@ func Collector[T, V any](ctx context.Context,
 // Merge creates a new input channel that returns the content of all the given
 // channels.
 func Merge[T any](ctx context.Context, chans ...<-chan T) <-chan T {
-	switch len(chans) {
-	case 0:
+	return MergeBuffered(ctx, 0, chans...)
+}
+
+// MergeBuffered works like Merge, but the output channel buffers up to size
+// values, which reduces contention when merging many producers.
+func MergeBuffered[T any](ctx context.Context, size int,
+	chans ...<-chan T) <-chan T {
+	switch {
+	case len(chans) == 0:
 		return nil
-	case 1:
+	case len(chans) == 1 && size == 0:
 		return chans[0]
 	}
 
-	c := make(chan T)
+	c := make(chan T, size)
 
 	wg := new(sync.WaitGroup)
 	wg.Add(len(chans))
 
-	fanout := func(wg *sync.WaitGroup, in <-chan T, out chan<- T) {
+	fanin := func(ctx context.Context, wg *sync.WaitGroup, in <-chan T,
+		out chan<- T) {
 		defer wg.Done()
 
 		for v := range in {
-			out <- v
+			select {
+			case <-ctx.Done():
+				return
+			case out <- v:
+			}
 		}
 	}
 
 	for i := range chans {
-		go fanout(wg, OrDone(ctx, chans[i]), c)
+		go fanin(ctx, wg, OrDone(ctx, chans[i]), c)
 	}
 
 	go func(wg *sync.WaitGroup, ch chan<- T) {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"runtime"
 	"sort"
 	"sync"
 	"testing"
@@ -410,6 +411,50 @@ func (s *PipeTestSuite) TestMerge() {
 	})
 }
 
+// TestMergeLeak is not parallel, so that the number of goroutines is not
+// affected by other tests.
+func TestMergeLeak(t *testing.T) {
+	const (
+		producers = 10
+		size      = 10
+	)
+
+	before := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	chans := make([]<-chan int, producers)
+
+	for i := range chans {
+		c := make(chan int, size)
+
+		for j := 0; j < size; j++ {
+			c <- j
+		}
+
+		chans[i] = c
+	}
+
+	merge := Merge(ctx, chans...)
+
+	for i := 0; i < producers; i++ {
+		<-merge
+	}
+
+	// The consumer stops reading the channel after the cancellation.
+	cancel()
+
+	deadline := time.Now().Add(time.Second)
+
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	if n := runtime.NumGoroutine(); n > before {
+		t.Errorf("%d goroutines leaked", n-before)
+	}
+}
+
 func (s *PipeTestSuite) TestTee() {
 	s.Run("single tee", func() {
 		pipe := s.IntPipe(s.Ctx)
@@ -0,0 +1,165 @@
+package pipeline
+
+import (
+	"context"
+	"strings"
+)
+
+// Result holds either a value or an error of a fallible pipeline stage or
+// an asynchronous operation.
+type Result[T any] struct {
+	Val T
+	Err error
+}
+
+// ErrorPolicy defines how a fallible pipeline stage handles errors.
+type ErrorPolicy int
+
+// Error policies of fallible pipeline stages.
+const (
+	// StopOnError stops the stage and cancels its upstream on the first
+	// error.
+	StopOnError ErrorPolicy = iota
+	// SkipErrors skips the failed values and reports the first error.
+	SkipErrors
+	// CollectErrors skips the failed values and reports all the errors.
+	CollectErrors
+)
+
+// Errors holds the errors collected by a fallible pipeline stage.
+type Errors []error
+
+// Error returns the messages of all the errors.
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the errors.
+func (e Errors) Unwrap() []error {
+	return e
+}
+
+// Values sends the values of successful results to an output chan and
+// handles the errors according to the policy. Function wait blocks until
+// the output chan is closed and returns the first error, or all the errors
+// as Errors with CollectErrors policy, or the context error.
+func Values[T any](ctx context.Context, policy ErrorPolicy,
+	input <-chan Result[T]) (output <-chan T, wait func() error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	return values(ctx, cancel, policy, input)
+}
+
+// TryMap works like Map, but handles the errors of function mapFn according
+// to the policy. See Values for the description of function wait.
+func TryMap[T, V any](ctx context.Context, mapFn func(T) (V, error),
+	input <-chan T, policy ErrorPolicy) (output <-chan V, wait func() error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	return values(ctx, cancel, policy, Map(ctx, func(v T) (r Result[V]) {
+		r.Val, r.Err = mapFn(v)
+
+		return r
+	}, input))
+}
+
+// TryFilter works like Filter, but handles the errors of function filter
+// according to the policy. See Values for the description of function wait.
+func TryFilter[T any](ctx context.Context, filter func(T) (bool, error),
+	input <-chan T, policy ErrorPolicy) (output <-chan T, wait func() error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	collect := func(_ context.Context, in <-chan T) (r Result[T], ok bool) {
+		for v := range in {
+			if ok, r.Err = filter(v); ok || r.Err != nil {
+				r.Val = v
+
+				return r, true
+			}
+		}
+
+		return r, false
+	}
+
+	return values(ctx, cancel, policy, Collector(ctx, collect, input))
+}
+
+// TryGenerate works like Generate, but handles the errors of function gen
+// according to the policy. The generation stops when function gen returns
+// false. See Values for the description of function wait.
+func TryGenerate[T any](ctx context.Context,
+	gen func(context.Context) (T, bool, error), policy ErrorPolicy) (
+	output <-chan T, wait func() error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	var stop bool
+
+	return values(ctx, cancel, policy, Generate(ctx,
+		func(ctx context.Context) (r Result[T], ok bool) {
+			if stop {
+				return r, false
+			}
+
+			if r.Val, ok, r.Err = gen(ctx); !ok && r.Err != nil {
+				stop, ok = true, true
+			}
+
+			return r, ok
+		}))
+}
+
+func values[T any](ctx context.Context, cancel context.CancelFunc,
+	policy ErrorPolicy, input <-chan Result[T]) (
+	output <-chan T, wait func() error) {
+	var err error
+
+	c := make(chan T)
+	done := make(chan struct{})
+
+	go func(ctx context.Context, out chan<- T, in <-chan Result[T]) {
+		defer close(done)
+		defer close(out)
+		defer cancel()
+
+		var errs Errors
+
+		defer func() {
+			switch {
+			case len(errs) == 0:
+				err = ctx.Err()
+			case policy == CollectErrors:
+				err = errs
+			default:
+				err = errs[0]
+			}
+		}()
+
+		for r := range in {
+			if r.Err != nil {
+				if errs = append(errs, r.Err); policy == StopOnError {
+					return
+				}
+
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case out <- r.Val:
+			}
+		}
+	}(ctx, c, OrDone(ctx, input))
+
+	return c, func() error {
+		<-done
+
+		return err
+	}
+}
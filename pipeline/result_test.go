@@ -0,0 +1,170 @@
+package pipeline_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	. "github.com/denisss025/go-async/pipeline"
+	"github.com/stretchr/testify/assert"
+)
+
+func testCollect[T any](in <-chan T) (out []T) {
+	for v := range in {
+		out = append(out, v)
+	}
+
+	return out
+}
+
+func TestTryMap(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	errOdd := errors.New("odd")
+
+	half := func(i int) (int, error) {
+		if i%2 != 0 {
+			return 0, errOdd
+		}
+
+		return i / 2, nil
+	}
+
+	t.Run("no errors", func(t *testing.T) {
+		t.Parallel()
+
+		out, wait := TryMap(ctx, half, ToChan(ctx, 0, 2, 4), StopOnError)
+
+		assert.Equal(t, []int{0, 1, 2}, testCollect(out))
+		assert.NoError(t, wait())
+	})
+
+	t.Run("stop on error", func(t *testing.T) {
+		t.Parallel()
+
+		out, wait := TryMap(ctx, half, Range(ctx, 0, 100), StopOnError)
+
+		assert.Equal(t, []int{0}, testCollect(out))
+		assert.ErrorIs(t, wait(), errOdd)
+	})
+
+	t.Run("skip errors", func(t *testing.T) {
+		t.Parallel()
+
+		out, wait := TryMap(ctx, half, Range(ctx, 0, 6), SkipErrors)
+
+		assert.Equal(t, []int{0, 1, 2}, testCollect(out))
+		assert.ErrorIs(t, wait(), errOdd)
+	})
+
+	t.Run("collect errors", func(t *testing.T) {
+		t.Parallel()
+
+		out, wait := TryMap(ctx, half, Range(ctx, 0, 6), CollectErrors)
+
+		assert.Equal(t, []int{0, 1, 2}, testCollect(out))
+
+		var errs Errors
+
+		assert.True(t, errors.As(wait(), &errs))
+		assert.Equal(t, Errors{errOdd, errOdd, errOdd}, errs)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(ctx)
+
+		out, wait := TryMap(ctx, half, Range(ctx, 0, 100, 2), StopOnError)
+
+		<-out
+		cancel()
+
+		testCollect(out)
+		assert.ErrorIs(t, wait(), context.Canceled)
+	})
+}
+
+func TestTryFilter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	isEven := func(i int) (bool, error) {
+		if i < 0 {
+			return false, io.ErrUnexpectedEOF
+		}
+
+		return i%2 == 0, nil
+	}
+
+	out, wait := TryFilter(ctx, isEven, ToChan(ctx, 1, 2, -3, 4, 5, -6),
+		SkipErrors)
+
+	assert.Equal(t, []int{2, 4}, testCollect(out))
+	assert.ErrorIs(t, wait(), io.ErrUnexpectedEOF)
+
+	out, wait = TryFilter(ctx, isEven, ToChan(ctx, 1, 2, -3, 4, 5, -6),
+		StopOnError)
+
+	assert.Equal(t, []int{2}, testCollect(out))
+	assert.ErrorIs(t, wait(), io.ErrUnexpectedEOF)
+}
+
+func TestTryGenerate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	newGen := func() func(context.Context) (int, bool, error) {
+		var i int
+
+		return func(_ context.Context) (int, bool, error) {
+			switch i++; {
+			case i == 3:
+				return 0, true, io.ErrUnexpectedEOF
+			case i > 5:
+				return 0, false, io.EOF
+			default:
+				return i, true, nil
+			}
+		}
+	}
+
+	t.Run("stop on error", func(t *testing.T) {
+		t.Parallel()
+
+		out, wait := TryGenerate(ctx, newGen(), StopOnError)
+
+		assert.Equal(t, []int{1, 2}, testCollect(out))
+		assert.ErrorIs(t, wait(), io.ErrUnexpectedEOF)
+	})
+
+	t.Run("collect errors", func(t *testing.T) {
+		t.Parallel()
+
+		out, wait := TryGenerate(ctx, newGen(), CollectErrors)
+
+		assert.Equal(t, []int{1, 2, 4, 5}, testCollect(out))
+
+		var errs Errors
+
+		assert.True(t, errors.As(wait(), &errs))
+		assert.Equal(t, Errors{io.ErrUnexpectedEOF, io.EOF}, errs)
+	})
+}
+
+func TestValues(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	out, wait := Values(ctx, SkipErrors, ToChan(ctx,
+		Result[int]{Val: 1}, Result[int]{Err: io.EOF}, Result[int]{Val: 3}))
+
+	assert.Equal(t, []int{1, 3}, testCollect(out))
+	assert.ErrorIs(t, wait(), io.EOF)
+}
@@ -0,0 +1,242 @@
+package pipeline
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// Window holds the values whose timestamps fall into [Start, End). Its items
+// can be reduced with Accumulate over ToChan(ctx, w.Items...).
+type Window[T any] struct {
+	Start time.Time
+	End   time.Time
+	Items []T
+}
+
+// WindowOptions configures the windowing operators.
+type WindowOptions[T any] struct {
+	// EventTime returns the event time of a value. The arrival time is used
+	// when nil.
+	EventTime func(T) time.Time
+	// AllowedLateness is how far the watermark lags behind the latest event
+	// time. A window is emitted when the watermark passes its end. It is
+	// used only with EventTime.
+	AllowedLateness time.Duration
+	// Late is called for the values that arrive after all their windows are
+	// emitted. Late values are dropped when nil.
+	Late func(T)
+}
+
+// windowAssigner adds a value with the given timestamp to the open windows
+// unless the windows end before the watermark. It returns true when
+// the value is late, i.e. all its windows end before the watermark.
+type windowAssigner[T any] func(open []*Window[T], v T, ts,
+	watermark time.Time) (_ []*Window[T], late bool)
+
+// TumblingWindow collects values of an input channel to fixed-size,
+// non-overlapping windows.
+func TumblingWindow[T any](ctx context.Context, input <-chan T,
+	size time.Duration, opts WindowOptions[T]) <-chan Window[T] {
+	return SlidingWindow(ctx, input, size, size, opts)
+}
+
+// SlidingWindow collects values of an input channel to fixed-size windows
+// that start every slide duration, so that a value may fall into several
+// windows. When size is less than slide, the values that fall between
+// the windows are dropped. Panics when size or slide is not positive.
+func SlidingWindow[T any](ctx context.Context, input <-chan T, size,
+	slide time.Duration, opts WindowOptions[T]) <-chan Window[T] {
+	if size <= 0 || slide <= 0 {
+		panic("window size and slide must be greater than 0")
+	}
+
+	assign := func(open []*Window[T], v T, ts, watermark time.Time) (
+		_ []*Window[T], late bool) {
+		var assigned bool
+
+		start := ts.Truncate(slide)
+
+		for end := start.Add(size); end.After(ts); start, end =
+			start.Add(-slide), end.Add(-slide) {
+			if !end.After(watermark) {
+				late = true
+
+				continue
+			}
+
+			assigned = true
+
+			if w := findWindow(open, start); w != nil {
+				w.Items = append(w.Items, v)
+
+				continue
+			}
+
+			open = append(open, &Window[T]{Start: start, End: end, Items: []T{v}})
+		}
+
+		return open, late && !assigned
+	}
+
+	return windows(ctx, input, opts, assign)
+}
+
+// SessionWindow collects values of an input channel to windows that are
+// separated by at least gap duration without values. Panics when gap is not
+// positive.
+func SessionWindow[T any](ctx context.Context, input <-chan T,
+	gap time.Duration, opts WindowOptions[T]) <-chan Window[T] {
+	if gap <= 0 {
+		panic("session gap must be greater than 0")
+	}
+
+	assign := func(open []*Window[T], v T, ts, watermark time.Time) (
+		_ []*Window[T], late bool) {
+		session := &Window[T]{Start: ts, End: ts.Add(gap)}
+		if !session.End.After(watermark) {
+			return open, true
+		}
+
+		rest := open[:0]
+
+		for _, w := range open {
+			if w.Start.After(session.End) || session.Start.After(w.End) {
+				rest = append(rest, w)
+
+				continue
+			}
+
+			if w.Start.Before(session.Start) {
+				session.Start = w.Start
+			}
+
+			if w.End.After(session.End) {
+				session.End = w.End
+			}
+
+			session.Items = append(session.Items, w.Items...)
+		}
+
+		session.Items = append(session.Items, v)
+
+		return append(rest, session), false
+	}
+
+	return windows(ctx, input, opts, assign)
+}
+
+func findWindow[T any](open []*Window[T], start time.Time) *Window[T] {
+	for _, w := range open {
+		if w.Start.Equal(start) {
+			return w
+		}
+	}
+
+	return nil
+}
+
+func windows[T any](ctx context.Context, input <-chan T,
+	opts WindowOptions[T], assign windowAssigner[T]) <-chan Window[T] {
+	c := make(chan Window[T])
+
+	go func(ctx context.Context, out chan<- Window[T], in <-chan T) {
+		defer close(out)
+
+		var (
+			open      []*Window[T]
+			watermark time.Time
+			timer     *time.Timer
+			timeout   <-chan time.Time
+		)
+
+		// emit sends the windows that end before the watermark.
+		emit := func() bool {
+			sort.Slice(open, func(i, j int) bool {
+				if open[i].End.Equal(open[j].End) {
+					return open[i].Start.Before(open[j].Start)
+				}
+
+				return open[i].End.Before(open[j].End)
+			})
+
+			for len(open) > 0 && !open[0].End.After(watermark) {
+				select {
+				case <-ctx.Done():
+					return false
+				case out <- *open[0]:
+					open = open[1:]
+				}
+			}
+
+			return true
+		}
+
+		// schedule wakes the operator up when the first window ends, if
+		// the windows are based on the arrival time.
+		schedule := func() {
+			if timer != nil {
+				timer.Stop()
+				timer, timeout = nil, nil
+			}
+
+			if opts.EventTime == nil && len(open) > 0 {
+				timer = time.NewTimer(time.Until(open[0].End))
+				timeout = timer.C
+			}
+		}
+
+		defer func() {
+			if timer != nil {
+				timer.Stop()
+			}
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-in:
+				if !ok {
+					for _, w := range open {
+						if w.End.After(watermark) {
+							watermark = w.End
+						}
+					}
+
+					emit()
+
+					return
+				}
+
+				ts := time.Now()
+
+				if opts.EventTime != nil {
+					ts = opts.EventTime(v)
+
+					if wm := ts.Add(-opts.AllowedLateness); wm.After(watermark) {
+						watermark = wm
+					}
+				} else {
+					watermark = ts
+				}
+
+				var late bool
+
+				if open, late = assign(open, v, ts, watermark); late &&
+					opts.Late != nil {
+					opts.Late(v)
+				}
+			case watermark = <-timeout:
+			}
+
+			if !emit() {
+				return
+			}
+
+			schedule()
+		}
+	}(ctx, c, input)
+
+	return c
+}
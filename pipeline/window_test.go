@@ -0,0 +1,229 @@
+package pipeline_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/denisss025/go-async/pipeline"
+	"github.com/stretchr/testify/assert"
+)
+
+type testEvent struct {
+	At  time.Duration
+	Val int
+}
+
+var testEpoch = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func testEventTime(e testEvent) time.Time { return testEpoch.Add(e.At) }
+
+func testEvents(ats ...time.Duration) []testEvent {
+	events := make([]testEvent, len(ats))
+
+	for i, at := range ats {
+		events[i] = testEvent{At: at, Val: i}
+	}
+
+	return events
+}
+
+func testWindowVals(windows []Window[testEvent]) (vals [][]int) {
+	for _, w := range windows {
+		var v []int
+
+		for _, e := range w.Items {
+			v = append(v, e.Val)
+		}
+
+		vals = append(vals, v)
+	}
+
+	return vals
+}
+
+func TestTumblingWindow(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	opts := WindowOptions[testEvent]{EventTime: testEventTime}
+
+	t.Run("event time", func(t *testing.T) {
+		t.Parallel()
+
+		in := ToChan(ctx, testEvents(0, 5*time.Second, 10*time.Second,
+			19*time.Second, 35*time.Second)...)
+		windows := testCollect(TumblingWindow(ctx, in, 10*time.Second, opts))
+
+		assert.Equal(t, [][]int{{0, 1}, {2, 3}, {4}}, testWindowVals(windows))
+
+		if assert.Len(t, windows, 3) {
+			assert.Equal(t, testEpoch.Add(10*time.Second), windows[1].Start)
+			assert.Equal(t, testEpoch.Add(20*time.Second), windows[1].End)
+		}
+	})
+
+	t.Run("late data", func(t *testing.T) {
+		t.Parallel()
+
+		var late []int
+
+		opts := opts
+		opts.Late = func(e testEvent) { late = append(late, e.Val) }
+
+		in := ToChan(ctx, testEvents(0, 12*time.Second, 5*time.Second,
+			25*time.Second, 18*time.Second, 11*time.Second)...)
+		windows := testCollect(TumblingWindow(ctx, in, 10*time.Second, opts))
+
+		assert.Equal(t, [][]int{{0}, {1}, {3}}, testWindowVals(windows))
+		assert.Equal(t, []int{2, 4, 5}, late)
+	})
+
+	t.Run("allowed lateness", func(t *testing.T) {
+		t.Parallel()
+
+		opts := opts
+		opts.AllowedLateness = 10 * time.Second
+
+		in := ToChan(ctx, testEvents(0, 12*time.Second, 5*time.Second,
+			25*time.Second, 18*time.Second)...)
+		windows := testCollect(TumblingWindow(ctx, in, 10*time.Second, opts))
+
+		assert.Equal(t, [][]int{{0, 2}, {1, 4}, {3}}, testWindowVals(windows))
+	})
+
+	t.Run("arrival time", func(t *testing.T) {
+		t.Parallel()
+
+		in := make(chan int)
+		out := TumblingWindow(ctx, in, 10*time.Millisecond, WindowOptions[int]{})
+
+		in <- 1
+
+		w := <-out
+		assert.Equal(t, []int{1}, w.Items)
+		assert.Equal(t, 10*time.Millisecond, w.End.Sub(w.Start))
+
+		close(in)
+
+		assert.Empty(t, testCollect(out))
+	})
+
+	t.Run("accumulate", func(t *testing.T) {
+		t.Parallel()
+
+		in := ToChan(ctx, testEvents(0, time.Second, 2*time.Second,
+			11*time.Second)...)
+
+		var sums []int
+
+		for w := range TumblingWindow(ctx, in, 10*time.Second, opts) {
+			sum, err := Accumulate(ctx, func(s int, e testEvent) (int, error) {
+				return s + e.Val, nil
+			}, 0, ToChan(ctx, w.Items...))
+
+			assert.NoError(t, err)
+
+			sums = append(sums, sum)
+		}
+
+		assert.Equal(t, []int{3, 3}, sums)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(ctx)
+		out := TumblingWindow(ctx, make(chan int), time.Second, WindowOptions[int]{})
+
+		cancel()
+
+		assert.Empty(t, testCollect(out))
+	})
+}
+
+func TestSlidingWindow(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	opts := WindowOptions[testEvent]{EventTime: testEventTime}
+
+	t.Run("overlapping", func(t *testing.T) {
+		t.Parallel()
+
+		in := ToChan(ctx, testEvents(0, 6*time.Second, 12*time.Second)...)
+		windows := testCollect(SlidingWindow(ctx, in, 10*time.Second,
+			5*time.Second, opts))
+
+		assert.Equal(t, [][]int{{0}, {0, 1}, {1, 2}, {2}},
+			testWindowVals(windows))
+
+		if assert.Len(t, windows, 4) {
+			assert.Equal(t, testEpoch.Add(-5*time.Second), windows[0].Start)
+			assert.Equal(t, testEpoch.Add(20*time.Second), windows[3].End)
+		}
+	})
+
+	t.Run("gaps", func(t *testing.T) {
+		t.Parallel()
+
+		var late []int
+
+		opts := opts
+		opts.Late = func(e testEvent) { late = append(late, e.Val) }
+
+		in := ToChan(ctx, testEvents(0, 7*time.Second, 12*time.Second,
+			3*time.Second)...)
+		windows := testCollect(SlidingWindow(ctx, in, 5*time.Second,
+			10*time.Second, opts))
+
+		assert.Equal(t, [][]int{{0}, {2}}, testWindowVals(windows))
+		assert.Equal(t, []int{3}, late)
+	})
+}
+
+func TestSessionWindow(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("event time", func(t *testing.T) {
+		t.Parallel()
+
+		opts := WindowOptions[testEvent]{
+			EventTime:       testEventTime,
+			AllowedLateness: 15 * time.Second,
+		}
+
+		in := ToChan(ctx, testEvents(0, 3*time.Second, 20*time.Second,
+			8*time.Second, 22*time.Second, 40*time.Second)...)
+		windows := testCollect(SessionWindow(ctx, in, 5*time.Second, opts))
+
+		assert.Equal(t, [][]int{{0, 1, 3}, {2, 4}, {5}}, testWindowVals(windows))
+
+		if assert.Len(t, windows, 3) {
+			assert.Equal(t, testEpoch, windows[0].Start)
+			assert.Equal(t, testEpoch.Add(13*time.Second), windows[0].End)
+		}
+	})
+
+	t.Run("arrival time", func(t *testing.T) {
+		t.Parallel()
+
+		in := make(chan int)
+		out := SessionWindow(ctx, in, 10*time.Millisecond, WindowOptions[int]{})
+
+		in <- 1
+		in <- 2
+
+		assert.Equal(t, []int{1, 2}, (<-out).Items)
+
+		in <- 3
+		close(in)
+
+		w := testCollect(out)
+		if assert.Len(t, w, 1) {
+			assert.Equal(t, []int{3}, w[0].Items)
+		}
+	})
+}
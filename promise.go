@@ -0,0 +1,42 @@
+package async
+
+import (
+	"errors"
+
+	"github.com/denisss025/go-async/pipeline"
+)
+
+// ErrNilReject is the error of a Promise rejected with a nil error.
+var ErrNilReject = errors.New("async: promise rejected with nil error")
+
+// Promise is the writable side of a Future. It allows to settle a Future
+// manually, e.g. from a callback-based API.
+type Promise[T any] struct {
+	future *Future[T]
+}
+
+// NewPromise creates a new pending Promise.
+func NewPromise[T any]() *Promise[T] {
+	return &Promise[T]{future: newFuture[T]()}
+}
+
+// Resolve fulfils the Promise with value v. It returns false when
+// the Promise has already been settled.
+func (p *Promise[T]) Resolve(v T) bool {
+	return p.future.resolve(pipeline.Result[T]{Val: v})
+}
+
+// Reject rejects the Promise with error err, or with ErrNilReject when err
+// is nil. It returns false when the Promise has already been settled.
+func (p *Promise[T]) Reject(err error) bool {
+	if err == nil {
+		err = ErrNilReject
+	}
+
+	return p.future.resolve(pipeline.Result[T]{Err: err})
+}
+
+// Future returns the Future that holds the result of the Promise.
+func (p *Promise[T]) Future() *Future[T] {
+	return p.future
+}
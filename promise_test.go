@@ -0,0 +1,113 @@
+package async_test
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/denisss025/go-async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromise(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("resolve", func(t *testing.T) {
+		t.Parallel()
+
+		p := NewPromise[int]()
+
+		time.AfterFunc(time.Millisecond, func() { p.Resolve(5) })
+
+		five, err := p.Future().Await(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, five)
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		t.Parallel()
+
+		p := NewPromise[int]()
+		assert.True(t, p.Reject(io.EOF))
+
+		zero, err := p.Future().Await(ctx)
+		assert.ErrorIs(t, err, io.EOF)
+		assert.Zero(t, zero)
+	})
+
+	t.Run("reject nil", func(t *testing.T) {
+		t.Parallel()
+
+		p := NewPromise[int]()
+		assert.True(t, p.Reject(nil))
+
+		_, err := p.Future().Await(ctx)
+		assert.ErrorIs(t, err, ErrNilReject)
+		assert.Equal(t, Rejected, p.Future().State())
+	})
+
+	t.Run("settle once", func(t *testing.T) {
+		t.Parallel()
+
+		p := NewPromise[int]()
+		assert.True(t, p.Resolve(5))
+		assert.False(t, p.Resolve(10))
+		assert.False(t, p.Reject(io.EOF))
+
+		five, err := p.Future().Await(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, five)
+	})
+
+	t.Run("concurrent resolve", func(t *testing.T) {
+		t.Parallel()
+
+		const n = 10
+
+		p := NewPromise[int]()
+
+		var (
+			mu      sync.Mutex
+			settled int
+		)
+
+		wg := new(sync.WaitGroup)
+		wg.Add(n)
+
+		for i := 0; i < n; i++ {
+			go func(i int) {
+				defer wg.Done()
+
+				if p.Resolve(i) {
+					mu.Lock()
+					settled++
+					mu.Unlock()
+				}
+			}(i)
+		}
+
+		wg.Wait()
+
+		assert.Equal(t, 1, settled)
+	})
+
+	t.Run("then", func(t *testing.T) {
+		t.Parallel()
+
+		p := NewPromise[int]()
+
+		ten := p.Future().Then(ctx, func(_ context.Context, i int) (int, error) {
+			return i * 2, nil
+		})
+
+		p.Resolve(5)
+
+		v, err := ten.Await(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 10, v)
+	})
+}
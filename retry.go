@@ -0,0 +1,139 @@
+package async
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// Clock provides the current time and timers. It allows to test
+// time-dependent code without real sleeps.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// SystemClock is a Clock that uses the functions of package time.
+type SystemClock struct{}
+
+// Now returns the current local time.
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// After waits for the duration to elapse and then sends the current time
+// on the returned channel.
+func (SystemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// Backoff returns the delay before the next attempt. Attempts are counted
+// from 1, prev is the previous delay or zero before the first retry.
+type Backoff func(attempt int, prev time.Duration) time.Duration
+
+// FixedBackoff returns a Backoff with a constant delay.
+func FixedBackoff(d time.Duration) Backoff {
+	return func(_ int, _ time.Duration) time.Duration {
+		return d
+	}
+}
+
+// ExponentialBackoff returns a Backoff that doubles the initial delay after
+// every attempt, but never exceeds the maximum delay.
+func ExponentialBackoff(initial, maxDelay time.Duration) Backoff {
+	return func(attempt int, _ time.Duration) time.Duration {
+		d := initial
+
+		for i := 1; i < attempt && d < maxDelay; i++ {
+			d *= 2
+		}
+
+		if d > maxDelay {
+			d = maxDelay
+		}
+
+		return d
+	}
+}
+
+// DecorrelatedJitterBackoff returns a Backoff that picks a random delay
+// between the base delay and three times the previous delay, but never
+// exceeds the maximum delay.
+func DecorrelatedJitterBackoff(base, maxDelay time.Duration) Backoff {
+	return func(_ int, prev time.Duration) time.Duration {
+		if prev < base {
+			prev = base
+		}
+
+		d := base + time.Duration(rand.Int63n(int64(3*prev-base)+1))
+
+		if d > maxDelay {
+			d = maxDelay
+		}
+
+		return d
+	}
+}
+
+// RetryPolicy describes how Retry repeats failed attempts.
+type RetryPolicy struct {
+	// Backoff returns delays between attempts. No delay when nil.
+	Backoff Backoff
+	// MaxAttempts limits the number of attempts. No limit when zero.
+	MaxAttempts int
+	// MaxElapsed limits the time since the first attempt after which no
+	// more attempts are made. No limit when zero.
+	MaxElapsed time.Duration
+	// Retryable reports whether an error is worth another attempt.
+	// All errors are retryable when nil.
+	Retryable func(error) bool
+	// Clock is used to measure time and wait between attempts.
+	// SystemClock is used when nil.
+	Clock Clock
+}
+
+// Retry runs function fn asynchronously until it succeeds or the policy
+// stops the attempts, and returns a Future that will eventually hold
+// the result of the last attempt.
+func Retry[T any](ctx context.Context, policy RetryPolicy,
+	fn func(context.Context) (T, error)) *Future[T] {
+	clock := policy.Clock
+	if clock == nil {
+		clock = SystemClock{}
+	}
+
+	return Exec(ctx, func(ctx context.Context) (v T, err error) {
+		var delay time.Duration
+
+		start := clock.Now()
+
+		for attempt := 1; ; attempt++ {
+			if v, err = fn(ctx); err == nil || !policy.retry(attempt, err) {
+				return v, err
+			}
+
+			if policy.Backoff != nil {
+				delay = policy.Backoff(attempt, delay)
+			}
+
+			if policy.MaxElapsed > 0 &&
+				clock.Now().Add(delay).Sub(start) > policy.MaxElapsed {
+				return v, err
+			}
+
+			select {
+			case <-ctx.Done():
+				return v, ctx.Err()
+			case <-clock.After(delay):
+			}
+		}
+	})
+}
+
+func (p *RetryPolicy) retry(attempt int, err error) bool {
+	if p.MaxAttempts > 0 && attempt >= p.MaxAttempts {
+		return false
+	}
+
+	return p.Retryable == nil || p.Retryable(err)
+}
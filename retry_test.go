@@ -0,0 +1,187 @@
+package async_test
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/denisss025/go-async"
+	"github.com/stretchr/testify/assert"
+)
+
+type testClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	delays []time.Duration
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *testClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	c.delays = append(c.delays, d)
+
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+
+	return ch
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+func (c *testClock) Delays() []time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.delays
+}
+
+func testFlaky(failures int, err error) func(context.Context) (int, error) {
+	var attempts int
+
+	return func(_ context.Context) (int, error) {
+		if attempts++; attempts <= failures {
+			return 0, err
+		}
+
+		return attempts, nil
+	}
+}
+
+func TestRetry(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("succeed after failures", func(t *testing.T) {
+		t.Parallel()
+
+		clock := &testClock{}
+
+		v, err := Retry(ctx, RetryPolicy{
+			Backoff: FixedBackoff(time.Second),
+			Clock:   clock,
+		}, testFlaky(2, io.EOF)).Await(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, v)
+		assert.Equal(t, []time.Duration{time.Second, time.Second}, clock.Delays())
+	})
+
+	t.Run("max attempts", func(t *testing.T) {
+		t.Parallel()
+
+		clock := &testClock{}
+
+		_, err := Retry(ctx, RetryPolicy{
+			MaxAttempts: 3,
+			Clock:       clock,
+		}, testFlaky(5, io.EOF)).Await(ctx)
+
+		assert.ErrorIs(t, err, io.EOF)
+		assert.Len(t, clock.Delays(), 2)
+	})
+
+	t.Run("max elapsed", func(t *testing.T) {
+		t.Parallel()
+
+		clock := &testClock{}
+
+		_, err := Retry(ctx, RetryPolicy{
+			Backoff:    ExponentialBackoff(time.Second, time.Minute),
+			MaxElapsed: 10 * time.Second,
+			Clock:      clock,
+		}, testFlaky(10, io.EOF)).Await(ctx)
+
+		assert.ErrorIs(t, err, io.EOF)
+		assert.Equal(t, []time.Duration{
+			time.Second, 2 * time.Second, 4 * time.Second,
+		}, clock.Delays())
+	})
+
+	t.Run("not retryable", func(t *testing.T) {
+		t.Parallel()
+
+		clock := &testClock{}
+
+		_, err := Retry(ctx, RetryPolicy{
+			Retryable: func(err error) bool { return err == io.EOF },
+			Clock:     clock,
+		}, testFlaky(5, io.ErrClosedPipe)).Await(ctx)
+
+		assert.ErrorIs(t, err, io.ErrClosedPipe)
+		assert.Empty(t, clock.Delays())
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(ctx, time.Millisecond)
+
+		defer cancel()
+
+		_, err := Retry(ctx, RetryPolicy{
+			Backoff: FixedBackoff(time.Minute),
+		}, testFlaky(5, io.EOF)).Await(context.Background())
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	t.Parallel()
+
+	t.Run("exponential", func(t *testing.T) {
+		t.Parallel()
+
+		backoff := ExponentialBackoff(time.Second, 10*time.Second)
+
+		for i, d := range []time.Duration{
+			time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second,
+			10 * time.Second, 10 * time.Second,
+		} {
+			assert.Equal(t, d, backoff(i+1, 0))
+		}
+	})
+
+	t.Run("decorrelated jitter", func(t *testing.T) {
+		t.Parallel()
+
+		const (
+			base     = time.Second
+			maxDelay = 30 * time.Second
+		)
+
+		backoff := DecorrelatedJitterBackoff(base, maxDelay)
+
+		var d time.Duration
+
+		for i := 1; i < 100; i++ {
+			prev := d
+			if prev < base {
+				prev = base
+			}
+
+			d = backoff(i, d)
+
+			assert.GreaterOrEqual(t, d, base)
+			assert.LessOrEqual(t, d, maxDelay)
+			assert.LessOrEqual(t, d, 3*prev)
+		}
+	})
+}
@@ -0,0 +1,40 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/denisss025/go-async/pipeline"
+)
+
+// ErrTimeout is the error of a Future whose task did not finish in time.
+// Unlike the context error returned by Await, it means that the task itself
+// has been cancelled.
+var ErrTimeout = errors.New("async: task timed out")
+
+// WithTimeout rejects the Future with ErrTimeout and cancels the context
+// of its task when the Future is not settled within duration d. It returns
+// the same Future.
+func WithTimeout[T any](f *Future[T], d time.Duration) *Future[T] {
+	timer := time.AfterFunc(d, func() {
+		if f.resolve(pipeline.Result[T]{Err: ErrTimeout}) {
+			f.stop()
+		}
+	})
+
+	go func() {
+		<-f.done
+		timer.Stop()
+	}()
+
+	return f
+}
+
+// ExecWithTimeout works like Exec, but rejects the Future with ErrTimeout
+// and cancels the context of function fn when fn does not return within
+// duration d.
+func ExecWithTimeout[T any](ctx context.Context, d time.Duration,
+	fn func(context.Context) (T, error)) *Future[T] {
+	return WithTimeout(Exec(ctx, fn), d)
+}
@@ -0,0 +1,73 @@
+package async_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/denisss025/go-async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("in time", func(t *testing.T) {
+		t.Parallel()
+
+		v, err := ExecWithTimeout(ctx, time.Second,
+			testValue(5, time.Millisecond)).Await(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 5, v)
+	})
+
+	t.Run("timeout cancels the task", func(t *testing.T) {
+		t.Parallel()
+
+		cancelled := make(chan error, 1)
+
+		_, err := ExecWithTimeout(ctx, time.Millisecond,
+			func(ctx context.Context) (int, error) {
+				<-ctx.Done()
+				cancelled <- ctx.Err()
+
+				return 0, ctx.Err()
+			}).Await(ctx)
+
+		assert.ErrorIs(t, err, ErrTimeout)
+		assert.ErrorIs(t, <-cancelled, context.Canceled)
+	})
+
+	t.Run("stop waiting", func(t *testing.T) {
+		t.Parallel()
+
+		f := WithTimeout(Exec(ctx, testValue(5, 10*time.Millisecond)),
+			time.Second)
+
+		timeout, cancel := context.WithTimeout(ctx, time.Millisecond)
+
+		defer cancel()
+
+		_, err := f.Await(timeout)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.NotErrorIs(t, err, ErrTimeout)
+
+		v, err := f.Await(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, v)
+	})
+
+	t.Run("promise", func(t *testing.T) {
+		t.Parallel()
+
+		p := NewPromise[int]()
+		f := WithTimeout(p.Future(), time.Millisecond)
+
+		_, err := f.Await(ctx)
+		assert.ErrorIs(t, err, ErrTimeout)
+		assert.False(t, p.Resolve(5))
+	})
+}